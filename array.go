@@ -0,0 +1,208 @@
+package pg2mysql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultArrayDelimiter joins array elements headed for text columns when
+// no delimiter is configured.
+const DefaultArrayDelimiter = ","
+
+func IsArrayType(c *Column) bool {
+	return c.Type == "ARRAY"
+}
+
+// ArrayElementType returns the PostgreSQL type of the elements of an array
+// column, e.g. int4 for an integer[] column.
+func ArrayElementType(c *Column) string {
+	return strings.TrimPrefix(c.UDTName, "_")
+}
+
+// ParseArray parses a PostgreSQL array literal such as {a,"b c",NULL} into
+// its elements. Each element is nil for NULL, a string, or a nested
+// []interface{} for multidimensional arrays.
+func ParseArray(literal string) ([]interface{}, error) {
+	// arrays with lower bounds other than 1 are prefixed with their dimensions
+	if strings.HasPrefix(literal, "[") {
+		i := strings.Index(literal, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("malformed array literal %q", literal)
+		}
+		literal = literal[i+1:]
+	}
+
+	p := arrayParser{input: literal}
+	elements, err := p.parseArray()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("malformed array literal %q", literal)
+	}
+
+	return elements, nil
+}
+
+type arrayParser struct {
+	input string
+	pos   int
+}
+
+func (p *arrayParser) parseArray() ([]interface{}, error) {
+	if p.pos >= len(p.input) || p.input[p.pos] != '{' {
+		return nil, fmt.Errorf("malformed array literal %q", p.input)
+	}
+	p.pos++
+
+	elements := []interface{}{}
+	if p.pos < len(p.input) && p.input[p.pos] == '}' {
+		p.pos++
+		return elements, nil
+	}
+
+	for p.pos < len(p.input) {
+		var element interface{}
+		var err error
+
+		switch p.input[p.pos] {
+		case '{':
+			element, err = p.parseArray()
+		case '"':
+			element, err = p.parseQuoted()
+		default:
+			element = p.parseUnquoted()
+		}
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+
+		if p.pos >= len(p.input) {
+			break
+		}
+		switch p.input[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return elements, nil
+		default:
+			return nil, fmt.Errorf("malformed array literal %q", p.input)
+		}
+	}
+
+	return nil, fmt.Errorf("malformed array literal %q", p.input)
+}
+
+func (p *arrayParser) parseQuoted() (interface{}, error) {
+	var b strings.Builder
+	p.pos++
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch c {
+		case '\\':
+			p.pos++
+			if p.pos < len(p.input) {
+				b.WriteByte(p.input[p.pos])
+			}
+		case '"':
+			p.pos++
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+		p.pos++
+	}
+
+	return nil, fmt.Errorf("malformed array literal %q", p.input)
+}
+
+func (p *arrayParser) parseUnquoted() interface{} {
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != ',' && p.input[p.pos] != '}' {
+		p.pos++
+	}
+
+	element := strings.TrimSpace(p.input[start:p.pos])
+	if strings.EqualFold(element, "NULL") {
+		return nil
+	}
+	return element
+}
+
+// ArrayToJSON renders the elements of an array as a JSON array. Numeric and
+// boolean elements become JSON numbers and booleans, json elements are
+// embedded as documents and everything else becomes a JSON string.
+func ArrayToJSON(elements []interface{}, elementType string) (string, error) {
+	value, err := arrayToJSONValue(elements, elementType)
+	if err != nil {
+		return "", err
+	}
+
+	doc, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(doc), nil
+}
+
+func arrayToJSONValue(elements []interface{}, elementType string) ([]interface{}, error) {
+	values := make([]interface{}, len(elements))
+	for i, element := range elements {
+		switch e := element.(type) {
+		case nil:
+			values[i] = nil
+		case []interface{}:
+			nested, err := arrayToJSONValue(e, elementType)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = nested
+		case string:
+			switch elementType {
+			case "int2", "int4", "int8", "float4", "float8", "numeric", "oid":
+				if !json.Valid([]byte(e)) {
+					return nil, fmt.Errorf("%s element %q has no JSON representation", elementType, e)
+				}
+				values[i] = json.Number(e)
+			case "bool":
+				values[i] = e == "t" || e == "true"
+			case "json", "jsonb":
+				if !json.Valid([]byte(e)) {
+					return nil, fmt.Errorf("invalid json element %q", e)
+				}
+				values[i] = json.RawMessage(e)
+			default:
+				values[i] = e
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// ArrayToDelimited joins the elements of a one dimensional array with
+// delimiter. Arrays that could not be split back into the same elements,
+// because they are multidimensional, hold NULLs or hold elements containing
+// the delimiter, are rejected.
+func ArrayToDelimited(elements []interface{}, delimiter string) (string, error) {
+	values := make([]string, len(elements))
+	for i, element := range elements {
+		switch e := element.(type) {
+		case nil:
+			return "", errors.New("array with NULL elements cannot be stored as delimited text")
+		case []interface{}:
+			return "", errors.New("multidimensional array cannot be stored as delimited text")
+		case string:
+			if strings.Contains(e, delimiter) {
+				return "", fmt.Errorf("array element %q contains the delimiter %q", e, delimiter)
+			}
+			values[i] = e
+		}
+	}
+
+	return strings.Join(values, delimiter), nil
+}
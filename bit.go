@@ -0,0 +1,41 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MySQLMaxBitLength is the largest n of a MySQL BIT(n) column.
+const MySQLMaxBitLength = 64
+
+// IsBitStringType reports whether a PostgreSQL data type holds bit strings.
+func IsBitStringType(dataType string) bool {
+	return dataType == "bit" || dataType == "bit varying"
+}
+
+// BitsFit reports whether every value of the src bit string column fits the
+// dst BIT(n) column, whose length information_schema reports as its
+// numeric precision.
+func BitsFit(src, dst *Column) bool {
+	return src.MaxChars > 0 && src.MaxChars <= dst.NumericPrecision
+}
+
+// ParseBitString returns the value of a bit string such as 0101 as the
+// integer MySQL stores in a BIT column.
+func ParseBitString(bits string) (uint64, error) {
+	significant := strings.TrimLeft(bits, "0")
+	if significant == "" {
+		return 0, nil
+	}
+	if len(significant) > MySQLMaxBitLength {
+		return 0, fmt.Errorf("bit string of %d bits is too long", len(significant))
+	}
+	return strconv.ParseUint(significant, 2, 64)
+}
+
+// bitLengthExpression measures the significant bits of a bit string, which
+// the dst BIT(n) column must hold.
+func bitLengthExpression(db DB, src *Column) string {
+	return fmt.Sprintf("LENGTH(LTRIM(CAST(%s AS TEXT), '0'))", columnExpression(db, src))
+}
@@ -0,0 +1,68 @@
+package pg2mysql
+
+import "fmt"
+
+// DefaultMySQLCharset is the connection character set used when none is
+// configured.
+const DefaultMySQLCharset = "utf8"
+
+// IsByteLimitedType reports whether the length of a MySQL data type is
+// limited in bytes rather than characters.
+func IsByteLimitedType(dataType string) bool {
+	switch dataType {
+	case "tinytext", "text", "mediumtext", "longtext",
+		"tinyblob", "blob", "mediumblob", "longblob":
+		return true
+	}
+	return false
+}
+
+// IsUTF8MB3 reports whether a MySQL character set is the three byte utf8,
+// which cannot store characters outside the Basic Multilingual Plane.
+func IsUTF8MB3(charset string) bool {
+	return charset == "utf8" || charset == "utf8mb3"
+}
+
+// IsPostgreSQLTextType reports whether a PostgreSQL data type holds
+// character strings.
+func IsPostgreSQLTextType(dataType string) bool {
+	switch dataType {
+	case "character varying", "character", "text":
+		return true
+	}
+	return false
+}
+
+// LosesSupplementaryCharacters reports whether strings of the src column
+// are headed for a dst column that cannot store supplementary characters,
+// such as emoji.
+func LosesSupplementaryCharacters(src, dst *Column) bool {
+	return IsPostgreSQLTextType(src.Type) && IsUTF8MB3(dst.CharacterSet)
+}
+
+// HasSupplementaryCharacters reports whether s holds characters outside the
+// Basic Multilingual Plane.
+func HasSupplementaryCharacters(s string) bool {
+	for _, r := range s {
+		if r > 0xFFFF {
+			return true
+		}
+	}
+	return false
+}
+
+// lengthExpression measures strings the way the dst column limits them: in
+// bytes for byte limited types, in characters otherwise.
+func lengthExpression(db DB, src, dst *Column) string {
+	length := "LENGTH"
+	if IsByteLimitedType(dst.Type) {
+		length = "OCTET_LENGTH"
+	}
+	return fmt.Sprintf("%s(%s)", length, columnExpression(db, src))
+}
+
+// supplementaryCharactersCondition selects strings holding characters
+// outside the Basic Multilingual Plane.
+func supplementaryCharactersCondition(db DB, src *Column) string {
+	return fmt.Sprintf(`%s ~ '[\U00010000-\U0010FFFF]'`, columnExpression(db, src))
+}
@@ -0,0 +1,425 @@
+package pg2mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// rowCheck flags source rows holding a value of one column that cannot be
+// stored in the destination. A condition is an SQL predicate evaluated by
+// the source database; a check inspects the non-NULL value of expression
+// in Go, for rules that cannot be expressed in SQL.
+type rowCheck struct {
+	column     *Column
+	rule       string
+	condition  string
+	expression string
+	check      func(value interface{}) bool
+
+	// size measures offending values against limit, for rules about sizes,
+	// and sizeExpression measures them in SQL for conditions on their size
+	size           func(value interface{}) int64
+	sizeExpression string
+	limit          int64
+}
+
+type rowChecks []rowCheck
+
+// checkBuilder returns the checks selecting the source rows of a column
+// pair that cannot be stored, for type rules whose checks go beyond the
+// length of values.
+type checkBuilder func(db DB, conversions *Conversions, src, dst *Column) rowChecks
+
+// buildRowChecks returns the checks of each pair of columns: the check an
+// assessment declares in SQL, those its rule builds, or else a check of
+// the length of values.
+func buildRowChecks(db DB, conversions *Conversions, columns []IncompatibleColumns) rowChecks {
+	var checks rowChecks
+	for _, column := range columns {
+		src, dst := column.src, column.dst
+
+		switch {
+		case column.assessment.Check != "":
+			checks.addCondition(db, src, RuleType, checkCondition(db, src, column.assessment.Check))
+
+		case column.assessment.Verdict == VerdictIncompatible:
+			checks.addCondition(db, src, RuleType, columnExpression(db, src)+" IS NOT NULL")
+
+		case column.assessment.checks != nil:
+			checks = append(checks, column.assessment.checks(db, conversions, src, dst)...)
+
+		default:
+			checks = append(checks, lengthChecks(db, conversions, src, dst)...)
+		}
+	}
+
+	return checks
+}
+
+// lengthChecks compares values to the destination length, and strings to
+// the character set of the destination.
+func lengthChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	length := charLength
+	if IsByteLimitedType(dst.Type) {
+		length = byteLength
+	}
+	checks.addSizeCondition(db, src, RuleLength, lengthExpression(db, src, dst), length, dst.MaxChars)
+	if LosesSupplementaryCharacters(src, dst) {
+		checks.addCondition(db, src, RuleCharset, supplementaryCharactersCondition(db, src))
+	}
+	return checks
+}
+
+// jsonChecks checks values for validity and size as JSON documents.
+func jsonChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	checks.addCheck(src, RuleJSON, columnExpression(db, src), func(value interface{}) bool {
+		return IncompatibleJSON(value, dst.MaxChars)
+	}).sized(byteLength, dst.MaxChars)
+	return checks
+}
+
+// conversionChecks checks the values ConvertValue writes, read the way
+// SelectExpression reads them.
+func conversionChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	checks.addCheck(src, RuleConversion, conversions.SelectExpression(db, src, dst), convertedValueCheck(conversions, src, dst))
+	return checks
+}
+
+// jsonConversionChecks checks values converted to JSON documents, and the
+// length of values written as they are otherwise.
+func jsonConversionChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	if dst.Type == "json" {
+		return conversionChecks(db, conversions, src, dst)
+	}
+	return lengthChecks(db, conversions, src, dst)
+}
+
+// decimalChecks checks numbers against the precision and scale of the
+// destination.
+func decimalChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	overflow, loss := numericConditions(db, src, dst)
+	checks.addCondition(db, src, RuleRange, overflow)
+	checks.addCondition(db, src, RulePrecision, loss)
+	return checks
+}
+
+// integerChecks checks numbers against the range of the destination.
+func integerChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	checks.addCondition(db, src, RuleRange, strings.Join(integerRangeConditions(db, src, dst), " OR "))
+	return checks
+}
+
+// spatialChecks checks geometries for type, dimensions and SRID.
+func spatialChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	checks.addCondition(db, src, RuleSpatial, spatialCondition(db, src, dst)).
+		expression = fmt.Sprintf("ST_AsText(CAST(%s AS geometry))", columnExpression(db, src))
+	return checks
+}
+
+// bitChecks checks the significant bits of bit strings against the length
+// of the destination.
+func bitChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	checks.addSizeCondition(db, src, RuleLength, bitLengthExpression(db, src), significantBits, dst.NumericPrecision)
+	return checks
+}
+
+// enumLabelChecks checks labels against those of the destination.
+func enumLabelChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	if unmapped := conversions.UnmappedEnumLabels(src, dst); len(unmapped) > 0 {
+		checks.addCondition(db, src, RuleEnum, enumLabelCondition(db, src, unmapped))
+	}
+	return checks
+}
+
+// enumLengthChecks checks labels against the length of the destination.
+func enumLengthChecks(db DB, conversions *Conversions, src, dst *Column) rowChecks {
+	var checks rowChecks
+	checks.addSizeCondition(db, src, RuleLength,
+		fmt.Sprintf("LENGTH(CAST(%s AS TEXT))", columnExpression(db, src)), charLength, dst.MaxChars)
+	return checks
+}
+
+// addCondition adds a check flagging rows of column matching condition,
+// reporting the value of the column. Empty conditions are skipped.
+func (r *rowChecks) addCondition(db DB, column *Column, rule, condition string) *rowCheck {
+	if condition == "" {
+		return &rowCheck{}
+	}
+
+	*r = append(*r, rowCheck{
+		column:     column,
+		rule:       rule,
+		condition:  condition,
+		expression: columnExpression(db, column),
+	})
+	return &(*r)[len(*r)-1]
+}
+
+// addSizeCondition adds a check flagging rows of column whose size, as
+// sizeExpression measures it, exceeds limit.
+func (r *rowChecks) addSizeCondition(db DB, column *Column, rule, sizeExpression string, size func(value interface{}) int64, limit int64) {
+	check := r.addCondition(db, column, rule, fmt.Sprintf("%s > %d", sizeExpression, limit))
+	check.sized(size, limit)
+	check.sizeExpression = sizeExpression
+}
+
+func (r *rowChecks) addCheck(column *Column, rule, expression string, check func(value interface{}) bool) *rowCheck {
+	*r = append(*r, rowCheck{
+		column:     column,
+		rule:       rule,
+		expression: expression,
+		check:      check,
+	})
+	return &(*r)[len(*r)-1]
+}
+
+func (c *rowCheck) sized(size func(value interface{}) int64, limit int64) {
+	c.size = size
+	c.limit = limit
+}
+
+// failedCheck is a check failed by a row, with the value it inspected.
+type failedCheck struct {
+	check *rowCheck
+	value interface{}
+}
+
+// eachIncompatibleRow calls f with the key of every row of table that fails
+// one of the checks, and the checks it fails. The key is nil when no key
+// column is given.
+func eachIncompatibleRow(db DB, table *Table, key *Column, checks rowChecks, debug map[string]bool, f func(key interface{}, failed []failedCheck) error) error {
+	var selectList, filters []string
+	if key != nil {
+		selectList = append(selectList, db.ColumnNameForSelect(key.ActualName))
+	}
+
+	for _, check := range checks {
+		if check.condition != "" {
+			selectList = append(selectList, "("+check.condition+")")
+			filters = append(filters, "("+check.condition+")")
+		} else {
+			filters = append(filters, columnExpression(db, check.column)+" IS NOT NULL")
+		}
+		selectList = append(selectList, check.expression)
+	}
+
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s", strings.Join(selectList, ","), db.QuoteTable(table.ActualName), strings.Join(filters, " OR "))
+	if debug["sql"] {
+		fmt.Println("DEBUG eachIncompatibleRow SQL:", stmt)
+	}
+
+	rows, err := db.DB().Query(stmt)
+	if err != nil {
+		return fmt.Errorf("failed to select rows: %s", err)
+	}
+	defer rows.Close()
+
+	values := make([]interface{}, len(selectList))
+	scanArgs := make([]interface{}, len(selectList))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(scanArgs...); err != nil {
+			return fmt.Errorf("failed to scan row: %s", err)
+		}
+
+		var keyValue interface{}
+		next := 0
+		if key != nil {
+			keyValue = values[next]
+			next++
+		}
+
+		var failed []failedCheck
+		for i := range checks {
+			check := &checks[i]
+
+			var incompatible bool
+			if check.condition != "" {
+				incompatible = isTrue(values[next])
+				next++
+			}
+
+			value := values[next]
+			next++
+			if check.check != nil {
+				incompatible = value != nil && check.check(value)
+			}
+
+			if incompatible {
+				failed = append(failed, failedCheck{check: check, value: value})
+			}
+		}
+
+		if len(failed) > 0 {
+			if err = f(keyValue, failed); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed iterating through rows: %s", err)
+	}
+
+	if err = rows.Close(); err != nil {
+		return fmt.Errorf("failed closing rows: %s", err)
+	}
+
+	return nil
+}
+
+// countable reports whether the checks are all SQL conditions, which
+// countIncompatibleRows counts without reading the rows failing them.
+func (r rowChecks) countable() bool {
+	for _, check := range r {
+		if check.condition == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// countIncompatibleRows counts the rows of table failing the checks, and the
+// rows failing each rule of each column, in the database. Only the samples
+// of each finding are read.
+func countIncompatibleRows(db DB, table *Table, checks rowChecks, debug map[string]bool) (int64, []ColumnFinding, error) {
+	var findings []ColumnFinding
+	var conditions, sizes [][]string
+	var expressions []string
+	index := map[string]int{}
+	for i := range checks {
+		check := &checks[i]
+		name := check.column.ActualName + "\x00" + check.rule
+		f, ok := index[name]
+		if !ok {
+			f = len(findings)
+			index[name] = f
+			findings = append(findings, ColumnFinding{ColumnName: check.column.ActualName, Rule: check.rule, Limit: check.limit})
+			conditions = append(conditions, nil)
+			sizes = append(sizes, nil)
+			expressions = append(expressions, check.expression)
+		}
+		conditions[f] = append(conditions[f], "("+check.condition+")")
+		if check.sizeExpression != "" {
+			sizes[f] = append(sizes[f], fmt.Sprintf("max(CASE WHEN %s THEN %s END)", check.condition, check.sizeExpression))
+		}
+	}
+
+	var filters []string
+	selectList := []string{"count(1)"}
+	for f := range findings {
+		filters = append(filters, conditions[f]...)
+		selectList = append(selectList, fmt.Sprintf("count(CASE WHEN %s THEN 1 END)", strings.Join(conditions[f], " OR ")))
+		selectList = append(selectList, sizes[f]...)
+	}
+
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s", strings.Join(selectList, ","), db.QuoteTable(table.ActualName), strings.Join(filters, " OR "))
+	if debug["sql"] {
+		fmt.Println("DEBUG countIncompatibleRows SQL:", stmt)
+	}
+
+	var count int64
+	counts := make([]sql.NullInt64, len(selectList)-1)
+	scanArgs := []interface{}{&count}
+	for i := range counts {
+		scanArgs = append(scanArgs, &counts[i])
+	}
+	if err := db.DB().QueryRow(stmt).Scan(scanArgs...); err != nil {
+		return 0, nil, fmt.Errorf("failed to count rows: %s", err)
+	}
+
+	var counted []ColumnFinding
+	next := 0
+	for f, finding := range findings {
+		finding.RowCount = counts[next].Int64
+		next++
+		for range sizes[f] {
+			if counts[next].Int64 > finding.MaxSize {
+				finding.MaxSize = counts[next].Int64
+			}
+			next++
+		}
+		if finding.RowCount == 0 {
+			continue
+		}
+
+		samples, err := sampleValues(db, table, expressions[f], conditions[f], debug)
+		if err != nil {
+			return 0, nil, err
+		}
+		finding.Samples = samples
+		counted = append(counted, finding)
+	}
+
+	return count, counted, nil
+}
+
+// sampleValues returns the values of expression of the first rows of table
+// matching one of conditions, as many as a finding keeps.
+func sampleValues(db DB, table *Table, expression string, conditions []string, debug map[string]bool) ([]string, error) {
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s LIMIT %d",
+		expression, db.QuoteTable(table.ActualName), strings.Join(conditions, " OR "), MaxFindingSamples)
+	if debug["sql"] {
+		fmt.Println("DEBUG sampleValues SQL:", stmt)
+	}
+
+	rows, err := db.DB().Query(stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select samples: %s", err)
+	}
+	defer rows.Close()
+
+	var samples []string
+	for rows.Next() {
+		var value interface{}
+		if err = rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan sample: %s", err)
+		}
+		samples = append(samples, sampleValue(value))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed iterating through samples: %s", err)
+	}
+
+	return samples, nil
+}
+
+// isTrue interprets a scanned boolean expression, which MySQL returns as an
+// integer.
+func isTrue(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case int64:
+		return v != 0
+	case []byte:
+		return string(v) == "1"
+	}
+	return false
+}
+
+func charLength(value interface{}) int64 {
+	return int64(utf8.RuneCountInString(formatValue(value)))
+}
+
+func byteLength(value interface{}) int64 {
+	return int64(len(formatValue(value)))
+}
+
+func significantBits(value interface{}) int64 {
+	return int64(len(strings.TrimLeft(formatValue(value), "0")))
+}
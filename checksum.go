@@ -0,0 +1,559 @@
+package pg2mysql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Methods the verifier can compare tables with.
+const (
+	// VerifyMethodRows merges the rows of both sides ordered by id, or looks
+	// up every source row in the destination in tables without an id.
+	VerifyMethodRows = "rows"
+	// VerifyMethodChecksum compares a checksum of each table first. Rows of
+	// tables whose checksums differ are found by checksums of primary key
+	// ranges, or looked up one by one in tables without a primary key or id.
+	VerifyMethodChecksum = "checksum"
+)
+
+// TableChecksum is an order-independent digest of the rows of a table: the
+// number of rows and the sum of their digests.
+type TableChecksum struct {
+	RowCount int64
+	Sum      string
+}
+
+// checksumTexts returns the SQL spelling each column of table the same way
+// on both databases, the source side as converted for the destination, or
+// false when a column cannot be spelled alike. Renamed enum labels are
+// renamed in SQL, but NULLs written as the default of their column and
+// dates whose out of range policy is to clamp them or write NULL depend on
+// the destination, and are only compared row by row.
+func checksumTexts(src, dst DB, conversions *Conversions, srcTable, dstTable *Table) (srcTexts, dstTexts []string, ok bool) {
+	location, err := conversions.Location()
+	if err != nil {
+		return nil, nil, false
+	}
+
+	for _, srcColumn := range srcTable.Columns {
+		_, dstColumn, err := dstTable.GetColumn(srcColumn)
+		if err != nil {
+			return nil, nil, false
+		}
+
+		if srcColumn.IsNullable && conversions.writesDefaultForNull(dstColumn) {
+			return nil, nil, false
+		}
+		if _, _, ok := sourceDateRange(srcColumn, dstColumn); ok && IsDateType(srcColumn.Type) &&
+			conversions.OutOfRangePolicy(srcTable.ActualName, srcColumn.ActualName) != OutOfRangeFail {
+			return nil, nil, false
+		}
+
+		srcExpression := columnExpression(src, srcColumn)
+		if LosesFractionalSeconds(srcColumn, dstColumn) {
+			srcExpression = fractionalSecondsExpression(srcExpression, dstColumn.DatetimePrecision, dst.RoundsTime())
+		}
+
+		srcText, ok := src.ChecksumText(srcExpression, srcColumn, dstColumn, location)
+		if !ok {
+			return nil, nil, false
+		}
+		if labels := conversions.EnumLabels[srcColumn.UDTName]; len(labels) > 0 && IsEnumType(srcColumn) {
+			srcText = renamedLabelsText(srcText, labels)
+		}
+		dstText, ok := dst.ChecksumText(dst.ColumnNameForSelect(dstColumn.ActualName), dstColumn, srcColumn, location)
+		if !ok {
+			return nil, nil, false
+		}
+
+		srcTexts = append(srcTexts, srcText)
+		dstTexts = append(dstTexts, dstText)
+	}
+
+	return srcTexts, dstTexts, true
+}
+
+// renamedLabelsText returns SQL renaming the enum labels text spells.
+func renamedLabelsText(text string, labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for label := range labels {
+		names = append(names, label)
+	}
+	sort.Strings(names)
+
+	cases := make([]string, len(names))
+	for i, label := range names {
+		cases[i] = fmt.Sprintf("WHEN %s THEN %s", quoteLiteral(label), quoteLiteral(labels[label]))
+	}
+	return fmt.Sprintf("CASE %s %s ELSE %s END", text, strings.Join(cases, " "), text)
+}
+
+// GetTableChecksum computes the checksum of the rows of table, spelling each
+// row with texts.
+func GetTableChecksum(db DB, table *Table, texts []string, debug map[string]bool) (TableChecksum, error) {
+	stmt := fmt.Sprintf("SELECT count(1), COALESCE(SUM(%s), 0) FROM %s",
+		db.RowDigest(texts), db.QuoteTable(table.ActualName))
+	if debug["sql"] {
+		fmt.Println("DEBUG GetTableChecksum SQL:", stmt)
+	}
+
+	var checksum TableChecksum
+	if err := db.DB().QueryRow(stmt).Scan(&checksum.RowCount, &checksum.Sum); err != nil {
+		return TableChecksum{}, err
+	}
+
+	return checksum, nil
+}
+
+// CompareTableChecksums reports whether the checksums of the src and dst
+// tables match. ok is false when some column cannot be spelled alike on
+// both databases, so that the rows have to be compared instead.
+func CompareTableChecksums(src, dst DB, conversions *Conversions, srcTable, dstTable *Table, debug map[string]bool) (match, ok bool, err error) {
+	srcTexts, dstTexts, ok := checksumTexts(src, dst, conversions, srcTable, dstTable)
+	if !ok {
+		return false, false, nil
+	}
+
+	srcChecksum, err := GetTableChecksum(src, srcTable, srcTexts, debug)
+	if err != nil {
+		return false, false, fmt.Errorf("failed computing source checksum: %s", err)
+	}
+
+	dstChecksum, err := GetTableChecksum(dst, dstTable, dstTexts, debug)
+	if err != nil {
+		return false, false, fmt.Errorf("failed computing destination checksum: %s", err)
+	}
+
+	if debug["data"] {
+		fmt.Printf("DEBUG %s checksums %+v %+v\n", srcTable.ActualName, srcChecksum, dstChecksum)
+	}
+
+	return srcChecksum == dstChecksum, true, nil
+}
+
+// ChecksumLeafRows is the most rows a key range may hold on either side
+// before its rows are compared one digest at a time instead of being split
+// further.
+const ChecksumLeafRows = 100
+
+// KeyDifferences holds the keys of rows missing from the destination, of
+// rows only the destination holds, and of rows both hold with different
+// values.
+type KeyDifferences struct {
+	MissingIDs []string
+	ExtraIDs   []string
+	ChangedIDs []string
+}
+
+// UnmatchedIDs returns the keys of the source rows without a matching row
+// in the destination, missing or changed, in key order.
+func (d KeyDifferences) UnmatchedIDs() []string {
+	ids := append(append([]string(nil), d.MissingIDs...), d.ChangedIDs...)
+	sortIDs(ids)
+	return ids
+}
+
+// keyColumns returns the columns of the primary key of srcTable, or of its
+// id when it declares none, by their index in srcTable, with the matching
+// columns of dstTable. ok is false when the table has neither, or when a
+// key column is missing from dstTable.
+func keyColumns(srcTable, dstTable *Table) (indexes []int, srcKey, dstKey []*Column, ok bool) {
+	names := srcTable.PrimaryKey
+	if len(names) == 0 {
+		names = []string{IDColumn.ActualName}
+	}
+
+	for _, name := range names {
+		index, srcColumn, err := srcTable.GetColumn(&Column{ActualName: name, NormalizedName: strings.ToLower(name)})
+		if err != nil || srcColumn.SplitFrom != nil {
+			return nil, nil, nil, false
+		}
+		_, dstColumn, err := dstTable.GetColumn(srcColumn)
+		if err != nil {
+			return nil, nil, nil, false
+		}
+		indexes = append(indexes, index)
+		srcKey = append(srcKey, srcColumn)
+		dstKey = append(dstKey, dstColumn)
+	}
+
+	return indexes, srcKey, dstKey, true
+}
+
+// integerKey reports whether a key is one column holding integers on both
+// sides, whose ranges can be split arithmetically.
+func integerKey(srcKey, dstKey []*Column) bool {
+	if len(srcKey) != 1 {
+		return false
+	}
+
+	switch srcKey[0].Type {
+	case "smallint", "integer", "bigint":
+		return IsIntegerType(dstKey[0].Type)
+	}
+	return false
+}
+
+// keyTexts returns the texts of the key columns at indexes.
+func keyTexts(texts []string, indexes []int) []string {
+	key := make([]string, len(indexes))
+	for i, index := range indexes {
+		key[i] = texts[index]
+	}
+	return key
+}
+
+// keyTextID returns the key text of a row as reported, its columns
+// separated by commas.
+func keyTextID(text string) string {
+	return strings.NewReplacer("\x1f", ",", "\x1e", "NULL").Replace(text)
+}
+
+// keyRange selects the rows of a table whose keys lie in a range.
+type keyRange interface {
+	condition(k *keyRangeChecksums) (string, []interface{})
+}
+
+// integerKeyRange holds the integer keys from lo to hi, both included.
+type integerKeyRange struct {
+	lo, hi int64
+}
+
+func (r integerKeyRange) condition(k *keyRangeChecksums) (string, []interface{}) {
+	return fmt.Sprintf("%s BETWEEN %d AND %d", k.key, r.lo, r.hi), nil
+}
+
+// binaryKeyRange holds the keys whose sort keys are from lo, included, to
+// hi, excluded. A nil bound leaves the range open at that end.
+type binaryKeyRange struct {
+	lo, hi []byte
+}
+
+func (r binaryKeyRange) condition(k *keyRangeChecksums) (string, []interface{}) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+	if r.lo != nil {
+		conditions = append(conditions, fmt.Sprintf("%s >= %s", k.key, k.db.ParameterMarker(len(args))))
+		args = append(args, r.lo)
+	}
+	if r.hi != nil {
+		conditions = append(conditions, fmt.Sprintf("%s < %s", k.key, k.db.ParameterMarker(len(args))))
+		args = append(args, r.hi)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// keyRangeChecksums computes checksums of the rows of a table by key range.
+// key is the SQL of the key ranges are taken on, id the SQL of the key as
+// reported.
+type keyRangeChecksums struct {
+	db    DB
+	table *Table
+	key   string
+	id    string
+	texts []string
+	debug map[string]bool
+}
+
+func (k *keyRangeChecksums) checksum(r keyRange) (TableChecksum, error) {
+	condition, args := r.condition(k)
+	stmt := fmt.Sprintf("SELECT count(1), COALESCE(SUM(%s), 0) FROM %s WHERE %s",
+		k.db.RowDigest(k.texts), k.db.QuoteTable(k.table.ActualName), condition)
+	if k.debug["sql"] {
+		fmt.Println("DEBUG keyRangeChecksums SQL:", stmt)
+	}
+
+	var checksum TableChecksum
+	if err := k.db.DB().QueryRow(stmt, args...).Scan(&checksum.RowCount, &checksum.Sum); err != nil {
+		return TableChecksum{}, err
+	}
+	return checksum, nil
+}
+
+// digests returns the digest of each row in the key range, by key.
+func (k *keyRangeChecksums) digests(r keyRange) (map[string][]string, error) {
+	condition, args := r.condition(k)
+	stmt := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s",
+		k.id, k.db.RowDigest(k.texts), k.db.QuoteTable(k.table.ActualName), condition)
+	if k.debug["sql"] {
+		fmt.Println("DEBUG keyRangeChecksums SQL:", stmt)
+	}
+
+	rows, err := k.db.DB().Query(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	digests := map[string][]string{}
+	for rows.Next() {
+		var key, digest string
+		if err := rows.Scan(&key, &digest); err != nil {
+			return nil, err
+		}
+		digests[key] = append(digests[key], digest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return digests, rows.Close()
+}
+
+func (k *keyRangeChecksums) bounds() (min, max sql.NullInt64, err error) {
+	stmt := fmt.Sprintf("SELECT MIN(%s), MAX(%s) FROM %s", k.key, k.key, k.db.QuoteTable(k.table.ActualName))
+	err = k.db.DB().QueryRow(stmt).Scan(&min, &max)
+	return min, max, err
+}
+
+// nthKey returns the sort key of the row at offset n of the key range, in
+// sort key order, or nil when the range holds no more rows. The databases
+// have no MIN of byte strings alike, so the first key is read this way too.
+func (k *keyRangeChecksums) nthKey(r keyRange, n int64) ([]byte, error) {
+	condition, args := r.condition(k)
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY 1 LIMIT 1 OFFSET %d",
+		k.key, k.db.QuoteTable(k.table.ActualName), condition, n)
+	if k.debug["sql"] {
+		fmt.Println("DEBUG keyRangeChecksums SQL:", stmt)
+	}
+
+	var key []byte
+	err := k.db.DB().QueryRow(stmt, args...).Scan(&key)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return key, err
+}
+
+// FindDivergentKeys finds the rows that differ between the src and dst
+// tables the way pt-table-checksum does: the primary key space is split
+// into ranges, the ranges are checksummed on both sides, and ranges whose
+// checksums differ are split again until they are small enough to compare
+// row by row. Integer keys are split by value, other keys by the UTF-8
+// bytes of their text at the middle row of the range. ok is false when the
+// tables have no primary key or id, or columns that cannot be spelled alike
+// on both databases.
+func FindDivergentKeys(src, dst DB, conversions *Conversions, srcTable, dstTable *Table, debug map[string]bool) (differences KeyDifferences, ok bool, err error) {
+	indexes, srcKey, dstKey, ok := keyColumns(srcTable, dstTable)
+	if !ok {
+		return KeyDifferences{}, false, nil
+	}
+
+	srcTexts, dstTexts, ok := checksumTexts(src, dst, conversions, srcTable, dstTable)
+	if !ok {
+		return KeyDifferences{}, false, nil
+	}
+
+	if integerKey(srcKey, dstKey) {
+		srcRanges := &keyRangeChecksums{db: src, table: srcTable, key: src.ColumnNameForSelect(srcKey[0].ActualName), texts: srcTexts, debug: debug}
+		dstRanges := &keyRangeChecksums{db: dst, table: dstTable, key: dst.ColumnNameForSelect(dstKey[0].ActualName), texts: dstTexts, debug: debug}
+		srcRanges.id, dstRanges.id = srcRanges.key, dstRanges.key
+
+		err = compareIntegerKeys(srcRanges, dstRanges, &differences)
+	} else {
+		srcKeyText := src.KeyText(keyTexts(srcTexts, indexes))
+		dstKeyText := dst.KeyText(keyTexts(dstTexts, indexes))
+		srcRanges := &keyRangeChecksums{db: src, table: srcTable, key: src.BinarySortKey(srcKeyText), id: srcKeyText, texts: srcTexts, debug: debug}
+		dstRanges := &keyRangeChecksums{db: dst, table: dstTable, key: dst.BinarySortKey(dstKeyText), id: dstKeyText, texts: dstTexts, debug: debug}
+
+		err = compareBinaryKeys(srcRanges, dstRanges, &differences)
+		for _, ids := range [][]string{differences.MissingIDs, differences.ExtraIDs, differences.ChangedIDs} {
+			for i := range ids {
+				ids[i] = keyTextID(ids[i])
+			}
+		}
+	}
+	if err != nil {
+		return KeyDifferences{}, false, err
+	}
+
+	sortIDs(differences.MissingIDs)
+	sortIDs(differences.ExtraIDs)
+	sortIDs(differences.ChangedIDs)
+
+	return differences, true, nil
+}
+
+// compareIntegerKeys adds the keys of the rows differing between the
+// lowest and the highest integer key of either side to differences.
+func compareIntegerKeys(src, dst *keyRangeChecksums, differences *KeyDifferences) error {
+	var lo, hi sql.NullInt64
+	for _, ranges := range []*keyRangeChecksums{src, dst} {
+		min, max, err := ranges.bounds()
+		if err != nil {
+			return fmt.Errorf("failed reading id range of %s: %s", ranges.table.ActualName, err)
+		}
+		if min.Valid && (!lo.Valid || min.Int64 < lo.Int64) {
+			lo = min
+		}
+		if max.Valid && (!hi.Valid || max.Int64 > hi.Int64) {
+			hi = max
+		}
+	}
+
+	if !lo.Valid {
+		return nil
+	}
+	return compareIntegerKeyRange(src, dst, integerKeyRange{lo.Int64, hi.Int64}, differences)
+}
+
+// compareIntegerKeyRange adds the keys of the rows differing in the range
+// to differences.
+func compareIntegerKeyRange(src, dst *keyRangeChecksums, r integerKeyRange, differences *KeyDifferences) error {
+	srcChecksum, dstChecksum, err := keyRangeChecksumsOf(src, dst, r)
+	if err != nil || srcChecksum == dstChecksum {
+		return err
+	}
+
+	if r.lo == r.hi || (srcChecksum.RowCount <= ChecksumLeafRows && dstChecksum.RowCount <= ChecksumLeafRows) {
+		return compareKeyRangeRows(src, dst, r, differences)
+	}
+
+	// halve without overflowing at the ends of the int64 range
+	mid := r.lo/2 + r.hi/2 + (r.lo%2+r.hi%2)/2
+	if err = compareIntegerKeyRange(src, dst, integerKeyRange{r.lo, mid}, differences); err != nil {
+		return err
+	}
+	return compareIntegerKeyRange(src, dst, integerKeyRange{mid + 1, r.hi}, differences)
+}
+
+// compareBinaryKeys adds the keys of the rows differing from the lowest
+// sort key of either side on to differences.
+func compareBinaryKeys(src, dst *keyRangeChecksums, differences *KeyDifferences) error {
+	var lo []byte
+	for _, ranges := range []*keyRangeChecksums{src, dst} {
+		first, err := ranges.nthKey(binaryKeyRange{}, 0)
+		if err != nil {
+			return fmt.Errorf("failed reading key range of %s: %s", ranges.table.ActualName, err)
+		}
+		if first != nil && (lo == nil || bytes.Compare(first, lo) < 0) {
+			lo = first
+		}
+	}
+
+	if lo == nil {
+		return nil
+	}
+	return compareBinaryKeyRange(src, dst, binaryKeyRange{lo: lo}, differences)
+}
+
+// compareBinaryKeyRange adds the keys of the rows differing in the range to
+// differences. Ranges are split at the middle row of the side holding more
+// rows.
+func compareBinaryKeyRange(src, dst *keyRangeChecksums, r binaryKeyRange, differences *KeyDifferences) error {
+	srcChecksum, dstChecksum, err := keyRangeChecksumsOf(src, dst, r)
+	if err != nil || srcChecksum == dstChecksum {
+		return err
+	}
+
+	if srcChecksum.RowCount <= ChecksumLeafRows && dstChecksum.RowCount <= ChecksumLeafRows {
+		return compareKeyRangeRows(src, dst, r, differences)
+	}
+
+	larger, rowCount := src, srcChecksum.RowCount
+	if dstChecksum.RowCount > rowCount {
+		larger, rowCount = dst, dstChecksum.RowCount
+	}
+
+	mid, err := larger.nthKey(r, rowCount/2)
+	if err != nil {
+		return fmt.Errorf("failed reading key range of %s: %s", larger.table.ActualName, err)
+	}
+
+	// half the range sharing its first key can't be split off
+	if mid == nil || bytes.Equal(mid, r.lo) {
+		return compareKeyRangeRows(src, dst, r, differences)
+	}
+
+	if err = compareBinaryKeyRange(src, dst, binaryKeyRange{r.lo, mid}, differences); err != nil {
+		return err
+	}
+	return compareBinaryKeyRange(src, dst, binaryKeyRange{mid, r.hi}, differences)
+}
+
+func keyRangeChecksumsOf(src, dst *keyRangeChecksums, r keyRange) (TableChecksum, TableChecksum, error) {
+	srcChecksum, err := src.checksum(r)
+	if err != nil {
+		return TableChecksum{}, TableChecksum{}, fmt.Errorf("failed computing source checksum: %s", err)
+	}
+	dstChecksum, err := dst.checksum(r)
+	if err != nil {
+		return TableChecksum{}, TableChecksum{}, fmt.Errorf("failed computing destination checksum: %s", err)
+	}
+	return srcChecksum, dstChecksum, nil
+}
+
+func compareKeyRangeRows(src, dst *keyRangeChecksums, r keyRange, differences *KeyDifferences) error {
+	srcDigests, err := src.digests(r)
+	if err != nil {
+		return fmt.Errorf("failed reading source digests: %s", err)
+	}
+	dstDigests, err := dst.digests(r)
+	if err != nil {
+		return fmt.Errorf("failed reading destination digests: %s", err)
+	}
+
+	for key, srcKeyDigests := range srcDigests {
+		dstKeyDigests := dstDigests[key]
+		switch {
+		case len(dstKeyDigests) == 0:
+			differences.MissingIDs = append(differences.MissingIDs, key)
+		case !sameDigests(srcKeyDigests, dstKeyDigests):
+			differences.ChangedIDs = append(differences.ChangedIDs, key)
+		}
+	}
+	for key := range dstDigests {
+		if _, ok := srcDigests[key]; !ok {
+			differences.ExtraIDs = append(differences.ExtraIDs, key)
+		}
+	}
+
+	return nil
+}
+
+// sameDigests reports whether two lists of digests hold the same digests,
+// in any order.
+func sameDigests(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortIDs sorts ids as integers when they all are, and as text otherwise.
+func sortIDs(ids []string) {
+	numbers := make([]int64, len(ids))
+	for i, id := range ids {
+		number, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			sort.Strings(ids)
+			return
+		}
+		numbers[i] = number
+	}
+	sort.Sort(integerIDs{ids, numbers})
+}
+
+type integerIDs struct {
+	ids     []string
+	numbers []int64
+}
+
+func (s integerIDs) Len() int           { return len(s.ids) }
+func (s integerIDs) Less(i, j int) bool { return s.numbers[i] < s.numbers[j] }
+func (s integerIDs) Swap(i, j int) {
+	s.ids[i], s.ids[j] = s.ids[j], s.ids[i]
+	s.numbers[i], s.numbers[j] = s.numbers[j], s.numbers[i]
+}
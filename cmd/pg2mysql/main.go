@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	flags "github.com/jessevdk/go-flags"
     "pg2mysql/commands"
@@ -12,6 +13,10 @@ func main() {
 	parser.NamespaceDelimiter = "-"
 
 	_, err := parser.Parse()
+	if problems, ok := err.(*commands.ProblemsFound); ok {
+		log.Print(problems)
+		os.Exit(problems.Code)
+	}
 	if err != nil {
 		log.Fatalf("error: %s", err)
 	}
@@ -0,0 +1,173 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Index is a unique index of a destination table.
+type Index struct {
+	Name    string
+	Columns []string
+}
+
+// UniqueCollision is a group of source rows whose keys are distinct in
+// PostgreSQL but equal under the collation of a destination unique index.
+type UniqueCollision struct {
+	IndexName string   `json:"index_name" yaml:"index_name"`
+	Key       []string `json:"key" yaml:"key"`
+	RowIDs    []int    `json:"row_ids,omitempty" yaml:"row_ids,omitempty"`
+	RowCount  int64    `json:"row_count" yaml:"row_count"`
+}
+
+// IsCaseInsensitiveCollation reports whether a MySQL collation compares
+// strings regardless of case.
+func IsCaseInsensitiveCollation(collation string) bool {
+	return strings.HasSuffix(collation, "_ci")
+}
+
+// IsAccentInsensitiveCollation reports whether a MySQL collation compares
+// strings regardless of accents: those named _ai, and the _ci collations
+// not named _as, whose accent sensitivity follows their case sensitivity.
+func IsAccentInsensitiveCollation(collation string) bool {
+	return strings.Contains(collation, "_ai_") || strings.HasSuffix(collation, "_ai") ||
+		IsCaseInsensitiveCollation(collation) && !strings.Contains(collation, "_as_")
+}
+
+// accentedLetters are the Latin-1 and Latin Extended-A letters composed of
+// an ASCII letter and accents, and unaccentedLetters their ASCII letters.
+const (
+	accentedLetters   = "ÀÁÂÃÄÅÇÈÉÊËÌÍÎÏÑÒÓÔÕÖÙÚÛÜÝàáâãäåçèéêëìíîïñòóôõöùúûüýÿĀāĂăĄąĆćĈĉĊċČčĎďĒēĔĕĖėĘęĚěĜĝĞğĠġĢģĤĥĨĩĪīĬĭĮįİĴĵĶķĹĺĻļĽľŃńŅņŇňŌōŎŏŐőŔŕŖŗŘřŚśŜŝŞşŠšŢţŤťŨũŪūŬŭŮůŰűŲųŴŵŶŷŸŹźŻżŽž"
+	unaccentedLetters = "AAAAAACEEEEIIIINOOOOOUUUUYaaaaaaceeeeiiiinooooouuuuyyAaAaAaCcCcCcCcDdEeEeEeEeEeGgGgGgGgHhIiIiIiIiIJjKkLlLlLlNnNnNnOoOoOoRrRrRrSsSsSsSsTtTtUuUuUuUuUuUuWwYyYZzZzZz"
+)
+
+// isPostgreSQLStringColumn reports whether a source column holds strings,
+// including citext.
+func isPostgreSQLStringColumn(c *Column) bool {
+	return IsPostgreSQLTextType(c.Type) || c.Type == "USER-DEFINED" && c.UDTName == "citext"
+}
+
+// collationKey returns the SQL normalizing a source string column the way
+// MySQL compares it under the collation of the dst column: without
+// trailing spaces unless the collation is NO PAD, in lower case for
+// case-insensitive collations, and without the accents of Latin letters
+// for accent-insensitive ones. Other equivalences of a collation, such as
+// ligatures, are not emulated.
+func collationKey(db DB, src, dst *Column) string {
+	key := fmt.Sprintf("CAST(%s AS TEXT)", columnExpression(db, src))
+	if !dst.NoPad {
+		key = fmt.Sprintf("rtrim(%s)", key)
+	}
+	if IsCaseInsensitiveCollation(dst.Collation) {
+		key = fmt.Sprintf("lower(%s)", key)
+	}
+	if IsAccentInsensitiveCollation(dst.Collation) {
+		key = fmt.Sprintf("translate(%s, %s, %s)", key, quoteLiteral(accentedLetters), quoteLiteral(unaccentedLetters))
+	}
+	return key
+}
+
+// GetUniqueCollisions groups the rows of the src table by the key of each
+// unique index of the dst table holding strings, compared the way MySQL
+// compares them, and returns the groups with more than one row. Rows with
+// a NULL in their key never collide.
+func GetUniqueCollisions(db DB, src, dst *Table, debug map[string]bool) ([]UniqueCollision, error) {
+	_, idColumn, _ := src.GetColumn(&IDColumn)
+
+	var collisions []UniqueCollision
+	for _, index := range dst.UniqueIndexes {
+		var keys, filters []string
+		var hasStrings bool
+		for _, name := range index.Columns {
+			_, dstColumn, err := dst.GetColumn(&Column{ActualName: name, NormalizedName: strings.ToLower(name)})
+			if err != nil {
+				return nil, fmt.Errorf("failed to find column of index %s: %s", index.Name, err)
+			}
+			_, srcColumn, err := src.GetColumn(dstColumn)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find column of index %s in source: %s", index.Name, err)
+			}
+
+			key := columnExpression(db, srcColumn)
+			if isPostgreSQLStringColumn(srcColumn) {
+				key = collationKey(db, srcColumn, dstColumn)
+				hasStrings = true
+			}
+			keys = append(keys, key)
+			filters = append(filters, columnExpression(db, srcColumn)+" IS NOT NULL")
+		}
+
+		// keys without strings compare alike in both databases
+		if !hasStrings {
+			continue
+		}
+
+		ids := "NULL"
+		if idColumn != nil {
+			ids = fmt.Sprintf("string_agg(CAST(%s AS TEXT), ',' ORDER BY %s)", columnExpression(db, idColumn), columnExpression(db, idColumn))
+		}
+
+		stmt := fmt.Sprintf("SELECT %s, count(1), %s FROM %s WHERE %s GROUP BY %s HAVING count(1) > 1 ORDER BY %s",
+			strings.Join(keys, ","), ids, db.QuoteTable(src.ActualName), strings.Join(filters, " AND "),
+			strings.Join(keys, ","), strings.Join(keys, ","))
+		if debug["sql"] {
+			fmt.Println("DEBUG GetUniqueCollisions SQL:", stmt)
+		}
+
+		indexCollisions, err := queryUniqueCollisions(db, stmt, index, len(keys))
+		if err != nil {
+			return nil, fmt.Errorf("failed finding collisions of index %s: %s", index.Name, err)
+		}
+		collisions = append(collisions, indexCollisions...)
+	}
+
+	return collisions, nil
+}
+
+func queryUniqueCollisions(db DB, stmt string, index Index, keyLength int) ([]UniqueCollision, error) {
+	rows, err := db.DB().Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, keyLength+2)
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+
+	var collisions []UniqueCollision
+	for rows.Next() {
+		if err = rows.Scan(scanArgs...); err != nil {
+			return nil, err
+		}
+
+		collision := UniqueCollision{IndexName: index.Name}
+		for _, value := range values[:keyLength] {
+			collision.Key = append(collision.Key, formatValue(value))
+		}
+
+		if count, ok := values[keyLength].(int64); ok {
+			collision.RowCount = count
+		}
+
+		if ids, ok := values[keyLength+1].([]byte); ok {
+			for _, id := range strings.Split(string(ids), ",") {
+				n, err := strconv.Atoi(id)
+				if err != nil {
+					return nil, fmt.Errorf("unexpected id %q", id)
+				}
+				collision.RowIDs = append(collision.RowIDs, n)
+			}
+		}
+
+		collisions = append(collisions, collision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collisions, rows.Close()
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+
+	"pg2mysql"
+)
+
+// Exit codes of the validate and verify commands.
+const (
+	ExitClean          = 0
+	ExitRuntimeError   = 1
+	ExitDataProblems   = 2
+	ExitSchemaProblems = 3
+)
+
+// ProblemsFound is returned by a command that ran to completion but found
+// problems in the schema or the data. Code is the exit code to report them
+// with.
+type ProblemsFound struct {
+	Code    int
+	Message string
+}
+
+func (p *ProblemsFound) Error() string {
+	return p.Message
+}
+
+// validationProblems returns the problems validation found, tables that
+// failed to validate taking precedence over schema problems and schema
+// problems over data problems, or nil when there are none.
+func validationProblems(results []pg2mysql.ValidationResult) error {
+	var failedTables, schemaTables, dataTables int
+	for _, result := range results {
+		if result.Error != "" {
+			failedTables++
+		}
+		if len(result.SchemaProblems) > 0 {
+			schemaTables++
+		}
+		if result.IncompatibleRowCount > 0 || len(result.UniqueCollisions) > 0 {
+			dataTables++
+		}
+	}
+
+	switch {
+	case failedTables > 0:
+		return &ProblemsFound{Code: ExitRuntimeError, Message: fmt.Sprintf("failed to validate %d tables", failedTables)}
+	case schemaTables > 0:
+		return &ProblemsFound{Code: ExitSchemaProblems, Message: fmt.Sprintf("found schema problems in %d tables", schemaTables)}
+	case dataTables > 0:
+		return &ProblemsFound{Code: ExitDataProblems, Message: fmt.Sprintf("found incompatible data in %d tables", dataTables)}
+	}
+	return nil
+}
+
+// verificationProblems returns the problems verification found: tables
+// that failed to verify count as runtime errors, tables or columns missing
+// from either side and incompatible types as schema problems, missing or
+// extra rows as data problems.
+func verificationProblems(results []pg2mysql.VerificationResult) error {
+	var failedTables, schemaTables, dataTables int
+	for _, result := range results {
+		if result.Error != "" {
+			failedTables++
+		}
+		if len(result.SchemaProblems) > 0 {
+			schemaTables++
+		}
+		if result.MissingRowCount > 0 || result.ExtraRowCount > 0 {
+			dataTables++
+		}
+	}
+
+	switch {
+	case failedTables > 0:
+		return &ProblemsFound{Code: ExitRuntimeError, Message: fmt.Sprintf("failed to verify %d tables", failedTables)}
+	case schemaTables > 0:
+		return &ProblemsFound{Code: ExitSchemaProblems, Message: fmt.Sprintf("found schema problems in %d tables", schemaTables)}
+	case dataTables > 0:
+		return &ProblemsFound{Code: ExitDataProblems, Message: fmt.Sprintf("found differing rows in %d tables", dataTables)}
+	}
+	return nil
+}
@@ -15,6 +15,11 @@ type MigrateCommand struct {
 func (c *MigrateCommand) Execute([]string) error {
 	var dest pg2mysql.DB
 
+	location, err := PG2MySQL.Config.Conversions.Location()
+	if err != nil {
+		return fmt.Errorf("invalid time_zone: %s", err)
+	}
+
 	if strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "mysql") {
 		dest = pg2mysql.NewMySQLDB(
 			PG2MySQL.Config.Dest.Database,
@@ -23,6 +28,8 @@ func (c *MigrateCommand) Execute([]string) error {
 			PG2MySQL.Config.Dest.Host,
 			PG2MySQL.Config.Dest.Port,
 			PG2MySQL.Config.Dest.RoundTime,
+			location,
+			PG2MySQL.Config.Dest.Charset,
 		)
 	} else if strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "psql") ||
               strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "postgres") ||
@@ -37,7 +44,7 @@ func (c *MigrateCommand) Execute([]string) error {
 		)
 	}
 
-	err := dest.Open()
+	err = dest.Open()
 	if err != nil {
 		return fmt.Errorf("failed to open mysql connection: %s", err)
 	}
@@ -58,7 +65,7 @@ func (c *MigrateCommand) Execute([]string) error {
 	defer src.Close()
 
 	watcher := pg2mysql.NewStdoutPrinter()
-	err = pg2mysql.NewMigrator(src, dest, c.Truncate, watcher, c.Debug).Migrate()
+	err = pg2mysql.NewMigrator(src, dest, &PG2MySQL.Config.Conversions, c.Truncate, watcher, c.Debug).Migrate()
 	if err != nil {
 		return fmt.Errorf("failed migrating: %s", err)
 	}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
     "strings"
 
 	"pg2mysql"
@@ -9,11 +10,17 @@ import (
 
 type ValidateCommand struct {
     Debug map[string]bool `short:"d" long:"debug" description:"Set up debug options"`
+    Format string `long:"format" default:"text" choice:"text" choice:"json" choice:"yaml" choice:"junit" description:"Format of the results"`
 }
 
 func (c *ValidateCommand) Execute([]string) error {
 	var dest pg2mysql.DB
 
+	location, err := PG2MySQL.Config.Conversions.Location()
+	if err != nil {
+		return fmt.Errorf("invalid time_zone: %s", err)
+	}
+
 	if strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "mysql") {
 		dest = pg2mysql.NewMySQLDB(
 			PG2MySQL.Config.Dest.Database,
@@ -22,6 +29,8 @@ func (c *ValidateCommand) Execute([]string) error {
 			PG2MySQL.Config.Dest.Host,
 			PG2MySQL.Config.Dest.Port,
 			PG2MySQL.Config.Dest.RoundTime,
+			location,
+			PG2MySQL.Config.Dest.Charset,
 		)
 	} else if strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "psql") ||
               strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "postgres") ||
@@ -36,7 +45,7 @@ func (c *ValidateCommand) Execute([]string) error {
 		)
 	}
 
-	err := dest.Open()
+	err = dest.Open()
 	if err != nil {
 		return fmt.Errorf("failed to open mysql connection: %s", err)
 	}
@@ -56,23 +65,70 @@ func (c *ValidateCommand) Execute([]string) error {
 	}
 	defer src.Close()
 
-	results, err := pg2mysql.NewValidator(src, dest, c.Debug).Validate()
+	results, err := pg2mysql.NewValidator(src, dest, &PG2MySQL.Config.Conversions, c.Debug).Validate()
 	if err != nil {
 		return fmt.Errorf("failed to validate: %s", err)
 	}
 
+	if c.Format != pg2mysql.FormatText {
+		if err = pg2mysql.WriteValidationReport(os.Stdout, c.Format, results); err != nil {
+			return err
+		}
+		return validationProblems(results)
+	}
+
 	for _, result := range results {
 		switch {
+		case result.Error != "":
+			fmt.Printf("failed to validate %s: %s\n", result.TableName, result.Error)
+
 		case len(result.IncompatibleRowIDs) > 0:
 			fmt.Printf("found %d incompatible rows in %s with IDs %v\n", result.IncompatibleRowCount, result.TableName, result.IncompatibleRowIDs)
 
 		case result.IncompatibleRowCount > 0:
 			fmt.Printf("found %d incompatible rows in %s (which has no 'id' column)\n", result.IncompatibleRowCount, result.TableName)
 
-		default:
+		case len(result.UniqueCollisions) == 0 && len(result.SchemaProblems) == 0:
 			fmt.Printf("%s OK\n", result.TableName)
 		}
+
+		for _, problem := range result.SchemaProblems {
+			fmt.Printf("schema problem in %s: %s\n", result.TableName, problem)
+		}
+
+		for _, finding := range result.Findings {
+			printFinding(finding)
+		}
+
+		for _, collision := range result.UniqueCollisions {
+			if len(collision.RowIDs) > 0 {
+				fmt.Printf("found %d rows in %s colliding on unique index %s with key %q and IDs %v\n",
+					collision.RowCount, result.TableName, collision.IndexName, collision.Key, collision.RowIDs)
+			} else {
+				fmt.Printf("found %d rows in %s colliding on unique index %s with key %q\n",
+					collision.RowCount, result.TableName, collision.IndexName, collision.Key)
+			}
+		}
+	}
+
+	return validationProblems(results)
+}
+
+// printFinding prints the rule a column fails, indented under its table.
+func printFinding(finding pg2mysql.ColumnFinding) {
+	fmt.Printf("  %s: %s, %d rows", finding.ColumnName, finding.Rule, finding.RowCount)
+	if finding.Limit > 0 {
+		fmt.Printf(", largest %d of %d allowed", finding.MaxSize, finding.Limit)
+	}
+	if len(finding.RowIDs) > 0 {
+		fmt.Printf(", IDs %v", finding.RowIDs)
 	}
+	if finding.Warning {
+		fmt.Print(" (warning)")
+	}
+	fmt.Println()
 
-	return nil
+	for _, sample := range finding.Samples {
+		fmt.Printf("    %q\n", sample)
+	}
 }
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"os"
     "strings"
 
 	"pg2mysql"
@@ -9,11 +10,19 @@ import (
 
 type VerifyCommand struct{
     Debug map[string]bool `short:"d" long:"debug" description:"Set up debug options"`
+    Format string `long:"format" default:"text" choice:"text" choice:"json" choice:"yaml" choice:"junit" description:"Format of the results"`
+    Method string `long:"method" default:"rows" choice:"rows" choice:"checksum" choice:"diff" description:"Compare every row, checksums of whole tables first, or every column of rows joined by id"`
+    DiffFile string `long:"diff-file" description:"Write the columns the diff method finds differing to this file, as json lines"`
 }
 
 func (c *VerifyCommand) Execute([]string) error {
 	var dest pg2mysql.DB
 
+	location, err := PG2MySQL.Config.Conversions.Location()
+	if err != nil {
+		return fmt.Errorf("invalid time_zone: %s", err)
+	}
+
 	if strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "mysql") {
 		dest = pg2mysql.NewMySQLDB(
 			PG2MySQL.Config.Dest.Database,
@@ -22,6 +31,8 @@ func (c *VerifyCommand) Execute([]string) error {
 			PG2MySQL.Config.Dest.Host,
 			PG2MySQL.Config.Dest.Port,
 			PG2MySQL.Config.Dest.RoundTime,
+			location,
+			PG2MySQL.Config.Dest.Charset,
 		)
 	} else if strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "psql") ||
               strings.EqualFold(PG2MySQL.Config.Dest.Flavor, "postgres") ||
@@ -36,7 +47,7 @@ func (c *VerifyCommand) Execute([]string) error {
 		)
 	}
 
-	err := dest.Open()
+	err = dest.Open()
 	if err != nil {
 		return fmt.Errorf("failed to open mysql connection: %s", err)
 	}
@@ -56,11 +67,39 @@ func (c *VerifyCommand) Execute([]string) error {
 	}
 	defer src.Close()
 
-	watcher := pg2mysql.NewStdoutPrinter()
-	err = pg2mysql.NewVerifier(src, dest, c.Debug, watcher).Verify()
+	// reports are written once all tables are verified, in place of progress
+	collector := pg2mysql.NewVerificationCollector()
+	if c.Format == pg2mysql.FormatText {
+		collector.Watcher = pg2mysql.NewStdoutPrinter()
+	}
+
+	var diffWriter *pg2mysql.DiffWriter
+	if c.DiffFile != "" {
+		diffFile, err := os.Create(c.DiffFile)
+		if err != nil {
+			return fmt.Errorf("failed to create diff file: %s", err)
+		}
+		defer diffFile.Close()
+
+		diffWriter = pg2mysql.NewDiffWriter(diffFile)
+		diffWriter.Watcher = collector.Watcher
+		collector.Watcher = diffWriter
+	}
+
+	err = pg2mysql.NewVerifier(src, dest, &PG2MySQL.Config.Conversions, c.Method, c.Debug, collector).Verify()
 	if err != nil {
 		return fmt.Errorf("failed to verify: %s", err)
 	}
 
-	return nil
+	if diffWriter != nil && diffWriter.Err() != nil {
+		return diffWriter.Err()
+	}
+
+	if c.Format != pg2mysql.FormatText {
+		if err = pg2mysql.WriteVerificationReport(os.Stdout, c.Format, collector.Results); err != nil {
+			return err
+		}
+	}
+
+	return verificationProblems(collector.Results)
 }
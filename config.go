@@ -1,5 +1,7 @@
 package pg2mysql
 
+import "time"
+
 type Config struct {
 	Dest struct {
 		Flavor    string `yaml:"flavor"`
@@ -10,6 +12,7 @@ type Config struct {
 		Port      int    `yaml:"port"`
 		RoundTime bool   `yaml:"round_time"`
 		SSLMode   string `yaml:"ssl_mode"`
+		Charset   string `yaml:"charset"`
 	} `yaml:"dest"`
 
 	Source struct {
@@ -21,4 +24,55 @@ type Config struct {
 		Port     int    `yaml:"port"`
 		SSLMode  string `yaml:"ssl_mode"`
 	} `yaml:"source"`
+
+	Conversions Conversions `yaml:"conversions"`
+}
+
+// Conversions controls how values without a direct MySQL equivalent are
+// converted by validate, migrate and verify.
+type Conversions struct {
+	// ArrayDelimiter joins array elements stored in text columns
+	ArrayDelimiter string `yaml:"array_delimiter"`
+
+	// EnumLabels renames labels of PostgreSQL enum types, keyed by type name
+	EnumLabels map[string]map[string]string `yaml:"enum_labels"`
+
+	// TimeZone is the zone timestamps are written and compared in: "session"
+	// to leave them as the connections return them, "UTC" or a zone name
+	TimeZone string `yaml:"time_zone"`
+
+	// InetPrefix is "keep" to keep network prefixes of inet and cidr values,
+	// the default, or "drop" to write only their addresses
+	InetPrefix string `yaml:"inet_prefix"`
+
+	// SplitRanges writes range columns, keyed by table and column name, to a
+	// pair of destination columns holding their bounds
+	SplitRanges map[string]map[string]RangeColumns `yaml:"split_ranges"`
+
+	// NullDefaults writes the default of NOT NULL destination columns in
+	// place of NULLs
+	NullDefaults bool `yaml:"null_defaults"`
+
+	// OutOfRange is the policy for dates and timestamps outside the range of
+	// their destination column, keyed by table and column name: "fail", the
+	// default, "clamp" or "null"
+	OutOfRange map[string]map[string]string `yaml:"out_of_range"`
+
+	// TypeRuleConfigs add type rules, or override the built-in ones, for
+	// pairs of source and destination data types
+	TypeRuleConfigs []TypeRuleConfig `yaml:"type_rules"`
+
+	location       *time.Location
+	locationErr    error
+	locationLoaded bool
+
+	typeRules       TypeRules
+	typeRulesErr    error
+	typeRulesLoaded bool
+}
+
+// RangeColumns names the destination columns of the bounds of a range.
+type RangeColumns struct {
+	Lower string `yaml:"lower"`
+	Upper string `yaml:"upper"`
 }
@@ -0,0 +1,192 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// ConvertValue transforms a value scanned from the src column into the
+// value bound to the parameter for the dst column.
+func (c *Conversions) ConvertValue(src, dst *Column, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch {
+	case src.Type == "timestamp with time zone" || src.Type == "timestamp without time zone":
+		if t, ok := value.(time.Time); ok {
+			return c.convertTime(src, t)
+		}
+
+	case convertsInterval(src, dst):
+		seconds, err := parseSeconds(value)
+		if err != nil {
+			return nil, err
+		}
+		return ConvertInterval(seconds, dst)
+
+	case (src.Type == "time without time zone" || src.Type == "time with time zone") && dst.Type == "time":
+		if t, ok := value.(time.Time); ok {
+			return FormatTimeOfDay(t, dst.DatetimePrecision), nil
+		}
+
+	case src.Type == "date" && dst.Type == "date":
+		// bind the calendar date, so no time zone can move it to another day
+		if t, ok := value.(time.Time); ok {
+			return t.Format("2006-01-02"), nil
+		}
+
+	case IsNetworkAddressType(src.Type):
+		if b, ok := value.([]byte); ok {
+			return c.ConvertNetworkAddress(string(b), dst)
+		}
+
+	case src.Type == "macaddr":
+		if b, ok := value.([]byte); ok {
+			return ConvertMACAddress(string(b), dst)
+		}
+
+	case IsBitStringType(src.Type) && dst.Type == "bit":
+		if b, ok := value.([]byte); ok {
+			return ParseBitString(string(b))
+		}
+
+	case IsRangeType(src.Type) && dst.Type == "json":
+		if b, ok := value.([]byte); ok {
+			r, err := ParseRange(string(b))
+			if err != nil {
+				return nil, err
+			}
+			return RangeToJSON(r, src.Type)
+		}
+
+	case IsJSONType(src.Type) || IsHstoreType(src) && dst.Type == "json":
+		// MySQL refuses to build a JSON document from a binary string
+		if b, ok := value.([]byte); ok {
+			return string(b), nil
+		}
+
+	case IsEnumType(src):
+		switch v := value.(type) {
+		case []byte:
+			return c.EnumLabel(src, string(v)), nil
+		case string:
+			return c.EnumLabel(src, v), nil
+		}
+
+	case IsArrayType(src):
+		literal, ok := value.([]byte)
+		if !ok {
+			return value, nil
+		}
+
+		switch {
+		case dst.Type == "json":
+			elements, err := ParseArray(string(literal))
+			if err != nil {
+				return nil, err
+			}
+			return ArrayToJSON(elements, ArrayElementType(src))
+
+		case IsTextType(dst.Type):
+			elements, err := ParseArray(string(literal))
+			if err != nil {
+				return nil, err
+			}
+			return ArrayToDelimited(elements, c.arrayDelimiter())
+		}
+	}
+
+	return value, nil
+}
+
+// SelectExpression returns the expression that reads the src column from db
+// in the form ConvertValue expects for the dst column.
+func (c *Conversions) SelectExpression(db DB, src, dst *Column) string {
+	switch {
+	case convertsInterval(src, dst):
+		return intervalSecondsExpression(columnExpression(db, src))
+	case convertsSpatial(src, dst):
+		return spatialExpression(db, src, dst)
+	case IsHstoreType(src) && dst.Type == "json":
+		return fmt.Sprintf("hstore_to_json(%s)", columnExpression(db, src))
+	}
+	return columnExpression(db, src)
+}
+
+// ConvertScanArgs replaces each scanned value of a src row with the value
+// bound for the matching dst column. Values outside the range of their
+// column under the fail policy are left as they are, and the first of them
+// is returned as an *OutOfRangeError once the rest of the row is converted.
+func (c *Conversions) ConvertScanArgs(src, dst *Table, scanArgs []interface{}) error {
+	var outOfRange error
+	for i := range scanArgs {
+		iface, ok := scanArgs[i].(*interface{})
+		if !ok {
+			return fmt.Errorf("received unexpected type as scanArg: %T (should be *interface{})", scanArgs[i])
+		}
+
+		value, err := c.applyOutOfRangePolicy(src.ActualName, src.Columns[i], dst.Columns[i], *iface)
+		if rangeErr, ok := err.(*OutOfRangeError); ok {
+			if outOfRange == nil {
+				outOfRange = rangeErr
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to convert %s.%s: %s", src.ActualName, src.Columns[i].ActualName, err)
+		}
+
+		converted, err := c.ConvertValue(src.Columns[i], dst.Columns[i], value)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s.%s: %s", src.ActualName, src.Columns[i].ActualName, err)
+		}
+		scanArgs[i] = &converted
+	}
+
+	return outOfRange
+}
+
+// convertedValueCheck returns a check flagging values ConvertValue rejects,
+// or whose converted form does not fit the dst column.
+func convertedValueCheck(conversions *Conversions, src, dst *Column) func(value interface{}) bool {
+	return func(value interface{}) bool {
+		converted, err := conversions.ConvertValue(src, dst, value)
+		if err != nil {
+			return true
+		}
+
+		switch v := converted.(type) {
+		case string:
+			switch {
+			case dst.Type == "json":
+				return IncompatibleJSON(v, dst.MaxChars)
+			case IsUTF8MB3(dst.CharacterSet) && HasSupplementaryCharacters(v):
+				return true
+			case IsByteLimitedType(dst.Type):
+				return dst.MaxChars > 0 && int64(len(v)) > dst.MaxChars
+			}
+			return dst.MaxChars > 0 && int64(utf8.RuneCountInString(v)) > dst.MaxChars
+		case []byte:
+			return dst.MaxChars > 0 && int64(len(v)) > dst.MaxChars
+		}
+		return false
+	}
+}
+
+func (c *Conversions) arrayDelimiter() string {
+	if c.ArrayDelimiter == "" {
+		return DefaultArrayDelimiter
+	}
+	return c.ArrayDelimiter
+}
+
+// IsTextType reports whether a MySQL data type holds character strings.
+func IsTextType(dataType string) bool {
+	switch dataType {
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PostgreSQLDatetimePrecision is the fractional seconds precision
+// PostgreSQL stores when a time column does not declare one.
+const PostgreSQLDatetimePrecision = 6
+
+// IsTimeType reports whether a data type holds a time of day, with or
+// without a date, that can carry fractional seconds.
+func IsTimeType(dataType string) bool {
+	switch dataType {
+	case "timestamp with time zone", "timestamp without time zone",
+		"time with time zone", "time without time zone",
+		"datetime", "timestamp", "time":
+		return true
+	}
+	return false
+}
+
+// FractionalSecondsUnit returns the smallest step a column with the given
+// fractional seconds precision can hold, e.g. time.Millisecond for 3.
+func FractionalSecondsUnit(precision int64) time.Duration {
+	unit := time.Second
+	for i := int64(0); i < precision && unit > time.Microsecond; i++ {
+		unit /= 10
+	}
+	return unit
+}
+
+// FormatTimeOfDay formats the time of day of t as a MySQL TIME literal with
+// precision fractional digits. Times of day carrying an offset, as read
+// from time with time zone columns, are normalized to UTC first.
+func FormatTimeOfDay(t time.Time, precision int64) string {
+	t = t.UTC()
+
+	s := t.Format("15:04:05")
+	if precision > 0 {
+		fraction := t.Nanosecond() / int(FractionalSecondsUnit(precision))
+		s = fmt.Sprintf("%s.%0*d", s, precision, fraction)
+	}
+	return s
+}
+
+// LosesFractionalSeconds reports whether values of the src column can hold
+// fractional seconds the dst column drops.
+func LosesFractionalSeconds(src, dst *Column) bool {
+	return IsTimeType(src.Type) && IsTimeType(dst.Type) && dst.DatetimePrecision < src.DatetimePrecision
+}
+
+// fractionalSecondsExpression returns SQL rounding or truncating the times
+// of expression to precision fractional seconds digits, as a destination
+// column with that precision stores them.
+func fractionalSecondsExpression(expression string, precision int64, round bool) string {
+	divisor := "1" + strings.Repeat("0", int(PostgreSQLDatetimePrecision-precision))
+	excess := fmt.Sprintf("MOD(CAST(EXTRACT(MICROSECONDS FROM %s) AS BIGINT), %s)", expression, divisor)
+	if round {
+		return fmt.Sprintf("(%s + (CASE WHEN %s * 2 >= %s THEN %s ELSE 0 END - %s) * interval '1 microsecond')",
+			expression, excess, divisor, divisor, excess)
+	}
+	return fmt.Sprintf("(%s - %s * interval '1 microsecond')", expression, excess)
+}
+
+// GetFractionalSecondsLossRowCount counts the rows of table whose values in
+// the src column have more fractional seconds digits than the dst column
+// holds.
+func GetFractionalSecondsLossRowCount(db DB, table *Table, src, dst *Column, debug map[string]bool) (int64, error) {
+	divisor := "1" + strings.Repeat("0", int(PostgreSQLDatetimePrecision-dst.DatetimePrecision))
+	stmt := fmt.Sprintf("SELECT count(1) FROM %s WHERE MOD(CAST(EXTRACT(MICROSECONDS FROM %s) AS BIGINT), %s) <> 0",
+		db.QuoteTable(table.ActualName), columnExpression(db, src), divisor)
+	if debug["sql"] {
+		fmt.Println("DEBUG GetFractionalSecondsLossRowCount SQL:", stmt)
+	}
+
+	var count int64
+	if err := db.DB().QueryRow(stmt).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
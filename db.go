@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"os"
 	"strings"
     "sort"
 	"time"
@@ -15,20 +16,33 @@ type DB interface {
     GetDbName() string
     GetDriverName() string
 	GetSchemaRows() (*sql.Rows, error)
+	GetEnumLabels() (map[string][]string, error)
+	GetSpatialSRIDs() (map[string]map[string]int64, error)
+	GetNoPadCollations() (map[string]bool, error)
+	GetUniqueIndexes() (map[string][]Index, error)
+	GetPrimaryKeys() (map[string][]string, error)
 	DisableConstraints() error
 	EnableConstraints() error
 	ColumnNameForSelect(columnName string) string
+	QuoteIdentifier(name string) string
+	QuoteTable(tableName string) string
 	ParameterMarker(paramIndex int) string
+	ParameterForColumn(paramIndex int, src, dst *Column) string
 	DB() *sql.DB
-	NormalizeTime(time.Time) time.Time
-	ComparisonClause(paramIndex int, columnName string, columnType string) string
+	NormalizeTime(t time.Time, column *Column) time.Time
+	RoundsTime() bool
+	ComparisonClause(paramIndex int, src, dst *Column) string
+	ChecksumText(expression string, column, other *Column, location *time.Location) (string, bool)
+	RowDigest(texts []string) string
+	KeyText(texts []string) string
+	BinarySortKey(expression string) string
 }
 
 type Schema struct {
 	Tables map[string]*Table
 }
 
-func DumpColumns(src, dst []*Column) {
+func DumpColumns(src, dst []*Column, rules TypeRules) {
     var srcPad, dstPad int = 45, 45
     var srcIdx, dstIdx int
     var srcSide, dstSide string = "", ""
@@ -46,10 +60,7 @@ func DumpColumns(src, dst []*Column) {
             dstColumn := dst[dstIdx]
             dstSide = fmt.Sprintf( "    %3d: %s", dstIdx, dstColumn.ActualName)
             fmt.Printf("%-*s%-*s\n", srcPad, "", dstPad, dstSide)
-            dstColumnLength := ""
-            if dstColumn.MaxChars != 0 {
-                dstColumnLength = fmt.Sprintf("(%d)", dstColumn.MaxChars);
-            }
+            dstColumnLength := dstColumn.LengthSpec()
             dstSide = fmt.Sprintf( "         %s%s", dstColumn.Type, dstColumnLength)
             fmt.Printf("%-*s%-*s\n", srcPad, "", dstPad, dstSide)
             dstIdx++
@@ -59,10 +70,7 @@ func DumpColumns(src, dst []*Column) {
             srcColumn := src[srcIdx]
             srcSide = fmt.Sprintf( "    %3d: %s", srcIdx, srcColumn.ActualName)
             fmt.Printf("%-*s\n", srcPad, srcSide)
-            srcColumnLength := ""
-            if srcColumn.MaxChars != 0 {
-                srcColumnLength = fmt.Sprintf("(%d)", srcColumn.MaxChars);
-            }
+            srcColumnLength := srcColumn.LengthSpec()
             srcSide = fmt.Sprintf( "         %s%s", srcColumn.Type, srcColumnLength)
             fmt.Printf("%-*s\n", srcPad, srcSide)
             srcIdx++
@@ -77,30 +85,22 @@ func DumpColumns(src, dst []*Column) {
             dstSide = fmt.Sprintf( "    %3d: %s", dstIdx, dstColumn.ActualName)
             fmt.Printf("%-*s%-*s\n", srcPad, srcSide, dstPad, dstSide)
 
-            srcColumnLength := ""
-            if srcColumn.MaxChars != 0 {
-                srcColumnLength = fmt.Sprintf("(%d)", srcColumn.MaxChars);
-            }
-            dstColumnLength := ""
-            if dstColumn.MaxChars != 0 {
-                dstColumnLength = fmt.Sprintf("(%d)", dstColumn.MaxChars);
-            }
+            srcColumnLength := srcColumn.LengthSpec()
+            dstColumnLength := dstColumn.LengthSpec()
 
             srcSide = fmt.Sprintf( "         %s%s", srcColumn.Type, srcColumnLength)
             dstSide = fmt.Sprintf( "         %s%s", dstColumn.Type, dstColumnLength)
             fmt.Printf("%-*s%-*s\n", srcPad, srcSide, dstPad, dstSide)
 
-            StaticColumnAnalysis(srcColumn, dstColumn)
+            assessment := rules.Assess(srcColumn, dstColumn)
+            fmt.Printf("%-*s%s: %s\n", srcPad, "", assessment.Verdict, assessment.Reason)
             srcIdx++
             dstIdx++
         case 1:
             dstColumn := dst[dstIdx]
             dstSide = fmt.Sprintf( "    %3d: %s", dstIdx, dstColumn.ActualName)
             fmt.Printf("%-*s%-*s\n", srcPad, "", dstPad, dstSide)
-            dstColumnLength := ""
-            if dstColumn.MaxChars != 0 {
-                dstColumnLength = fmt.Sprintf("(%d)", dstColumn.MaxChars);
-            }
+            dstColumnLength := dstColumn.LengthSpec()
             dstSide = fmt.Sprintf( "         %s%s", dstColumn.Type, dstColumnLength)
             fmt.Printf("%-*s%-*s\n", srcPad, "", dstPad, dstSide)
             dstIdx++
@@ -108,10 +108,7 @@ func DumpColumns(src, dst []*Column) {
             srcColumn := src[srcIdx]
             srcSide = fmt.Sprintf( "    %3d: %s", srcIdx, srcColumn.ActualName)
             fmt.Printf("%-*s\n", srcPad, srcSide)
-            srcColumnLength := ""
-            if srcColumn.MaxChars != 0 {
-                srcColumnLength = fmt.Sprintf("(%d)", srcColumn.MaxChars);
-            }
+            srcColumnLength := srcColumn.LengthSpec()
             srcSide = fmt.Sprintf( "         %s%s", srcColumn.Type, srcColumnLength)
             fmt.Printf("%-*s\n", srcPad, srcSide)
             srcIdx++
@@ -119,7 +116,7 @@ func DumpColumns(src, dst []*Column) {
     }
 }
 
-func DumpSchema( src, dst *Schema, srcDB, dstDB DB) {
+func DumpSchema( src, dst *Schema, srcDB, dstDB DB, rules TypeRules) {
     var srcPad, dstPad int = 45, 45
 
     srcSide := fmt.Sprintf( "Driver: %s", srcDB.GetDriverName())
@@ -139,7 +136,7 @@ func DumpSchema( src, dst *Schema, srcDB, dstDB DB) {
             dstTable := dst.Tables[dstTableNames[dstIdx]]
             dstSide = fmt.Sprintf( "  Table: %s", dstTable.ActualName)
             fmt.Printf("%-*s%-*s\n", srcPad, "", dstPad, dstSide)
-            DumpColumns(make([]*Column, 0), dstTable.Columns)
+            DumpColumns(make([]*Column, 0), dstTable.Columns, rules)
             dstIdx++
             continue
         }
@@ -147,7 +144,7 @@ func DumpSchema( src, dst *Schema, srcDB, dstDB DB) {
             srcTable := src.Tables[srcTableNames[srcIdx]]
             srcSide = fmt.Sprintf( "  Table: %s", srcTable.ActualName)
             fmt.Printf("%-*s\n", srcPad, srcSide)
-            DumpColumns(srcTable.Columns, make([]*Column, 0))
+            DumpColumns(srcTable.Columns, make([]*Column, 0), rules)
             srcIdx++
             continue
         }
@@ -160,20 +157,20 @@ func DumpSchema( src, dst *Schema, srcDB, dstDB DB) {
             srcSide = fmt.Sprintf( "  Table: %s", srcTable.ActualName)
             dstSide = fmt.Sprintf( "  Table: %s", dstTable.ActualName)
             fmt.Printf("%-*s%-*s\n", srcPad, srcSide, dstPad, dstSide)
-            DumpColumns(srcTable.Columns, dstTable.Columns)
+            DumpColumns(srcTable.Columns, dstTable.Columns, rules)
             srcIdx++
             dstIdx++
         case 1:
             dstTable := dst.Tables[dstTableNames[dstIdx]]
             dstSide = fmt.Sprintf( "  Table: %s", dstTable.ActualName)
             fmt.Printf("%-*s%-*s\n", srcPad, "", dstPad, dstSide)
-            DumpColumns(make([]*Column, 0), dstTable.Columns)
+            DumpColumns(make([]*Column, 0), dstTable.Columns, rules)
             dstIdx++
         case -1:
             srcTable := src.Tables[srcTableNames[srcIdx]]
             srcSide = fmt.Sprintf( "  Table: %s", srcTable.ActualName)
             fmt.Printf("%-*s\n", srcPad, srcSide)
-            DumpColumns(srcTable.Columns, make([]*Column, 0))
+            DumpColumns(srcTable.Columns, make([]*Column, 0), rules)
             srcIdx++
         }
     }
@@ -193,7 +190,7 @@ func (s *Schema) DumpSchema() {
 func DumpTableColumns( indent int, columns []*Column ) {
     for i, column := range columns { 
         fmt.Printf("%*s%4d: Name: %s %s\n", indent, "", i, column.ActualName, column.NormalizedName)
-        fmt.Printf("%*sType: %v %v\n", indent + 8, "", column.Type, column.MaxChars)
+        fmt.Printf("%*sType: %v%s\n", indent + 8, "", column.Type, column.LengthSpec())
     }
 }
 
@@ -208,6 +205,8 @@ type Table struct {
 	ActualName    string
 	NormalizedName    string
 	Columns []*Column
+	UniqueIndexes []Index
+	PrimaryKey    []string
 }
 
 func (t *Table) HasIDColumn(other *Table, debug map[string]bool) bool {
@@ -232,7 +231,7 @@ func (t *Table) GetColumn(other *Column) (int, *Column, error) {
 	for i, column := range t.Columns {
 		if column.NormalizedName == other.NormalizedName {
             if column.ActualName != other.ActualName {
-                fmt.Printf( "Warning: Actual columns do not match %s - %s\n", column.ActualName, other.ActualName )
+                fmt.Fprintf(os.Stderr, "Warning: Actual columns do not match %s - %s\n", column.ActualName, other.ActualName)
             }
 			return i, column, nil
 		}
@@ -245,6 +244,39 @@ type Column struct {
     NormalizedName string
 	Type           string
 	MaxChars       int64
+	UDTName        string
+	ColumnType     string
+	EnumLabels     []string
+
+	NumericPrecision  int64
+	NumericScale      int64
+	DatetimePrecision int64
+	CharacterSet      string
+	Collation         string
+	IsNullable        bool
+	Default           *string
+
+	// SRID is the SRID a MySQL spatial column restricts its values to, if
+	// any
+	SRID *int64
+
+	// NoPad is set for columns of MySQL collations that compare trailing
+	// spaces rather than ignore them
+	NoPad bool
+
+	// SplitFrom is the range column whose Bound, lower or upper, a column
+	// split off by SplitRangeColumns reads
+	SplitFrom *Column
+	Bound     string
+}
+
+// columnExpression returns the SQL reading a source column: its quoted
+// name, or the bound of the range it was split from.
+func columnExpression(db DB, c *Column) string {
+	if c.SplitFrom != nil {
+		return fmt.Sprintf("%s(%s)", c.Bound, db.QuoteIdentifier(c.SplitFrom.ActualName))
+	}
+	return db.QuoteIdentifier(c.ActualName)
 }
 
 var IDColumn Column = Column {
@@ -252,25 +284,16 @@ var IDColumn Column = Column {
     NormalizedName: "id",
 }
 
-func (c *Column) Compatible(other *Column) bool {
-	if c.MaxChars == 0 && other.MaxChars == 0 {
-		return true
+// LengthSpec returns the length, or the precision and scale, of the column
+// the way it is spelled in a column definition, e.g. (255) or (10,2).
+func (c *Column) LengthSpec() string {
+	switch {
+	case c.MaxChars != 0:
+		return fmt.Sprintf("(%d)", c.MaxChars)
+	case IsDecimalType(c.Type) && c.NumericPrecision != 0:
+		return fmt.Sprintf("(%d,%d)", c.NumericPrecision, c.NumericScale)
 	}
-
-	if c.MaxChars > 0 && other.MaxChars > 0 {
-		return c.MaxChars < other.MaxChars
-	}
-
-    if (c.Type == "uuid" && (other.Type == "varbinary" || other.Type == "binary") && other.MaxChars == 16) || 
-       ((c.Type == "varbinary" || c.Type == "binary") && c.MaxChars == 16 && other.Type == "uuid") {
-        return true
-    }
-
-	return false
-}
-
-func (c *Column) Incompatible(other *Column) bool {
-	return !c.Compatible(other)
+	return ""
 }
 
 func BuildSchema(db DB) (*Schema, error) {
@@ -286,17 +309,43 @@ func BuildSchema(db DB) (*Schema, error) {
 			column   sql.NullString
 			datatype sql.NullString
 			maxChars sql.NullInt64
+			udtName  sql.NullString
+			colType  sql.NullString
+			numericPrecision  sql.NullInt64
+			numericScale      sql.NullInt64
+			datetimePrecision sql.NullInt64
+			characterSet      sql.NullString
+			collation         sql.NullString
+			isNullable        sql.NullString
+			columnDefault     sql.NullString
 		)
 
-		if err := rows.Scan(&table, &column, &datatype, &maxChars); err != nil {
+		if err := rows.Scan(&table, &column, &datatype, &maxChars, &udtName, &colType,
+			&numericPrecision, &numericScale, &datetimePrecision, &characterSet, &collation,
+			&isNullable, &columnDefault); err != nil {
 			return nil, err
 		}
 
+		var defaultValue *string
+		if columnDefault.Valid {
+			defaultValue = &columnDefault.String
+		}
+
 		data[table.String] = append(data[table.String], &Column{
 			ActualName:     column.String,
 			NormalizedName: strings.ToLower(column.String),
 			Type:           datatype.String,
 			MaxChars:       maxChars.Int64,
+			UDTName:        udtName.String,
+			ColumnType:     colType.String,
+			EnumLabels:     ParseEnumColumnType(colType.String),
+			NumericPrecision:  numericPrecision.Int64,
+			NumericScale:      numericScale.Int64,
+			DatetimePrecision: datetimePrecision.Int64,
+			CharacterSet:      characterSet.String,
+			Collation:         collation.String,
+			IsNullable:        isNullable.String == "YES",
+			Default:           defaultValue,
 		})
 	}
 
@@ -308,16 +357,72 @@ func BuildSchema(db DB) (*Schema, error) {
 		return nil, fmt.Errorf("failed closing rows: %s", err)
 	}
 
+	enumLabels, err := db.GetEnumLabels()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enum labels: %s", err)
+	}
+
+	for _, columns := range data {
+		for _, column := range columns {
+			if labels, ok := enumLabels[column.UDTName]; ok && column.Type == "USER-DEFINED" {
+				column.EnumLabels = labels
+			}
+		}
+	}
+
+	noPadCollations, err := db.GetNoPadCollations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collations: %s", err)
+	}
+
+	for _, columns := range data {
+		for _, column := range columns {
+			column.NoPad = noPadCollations[column.Collation]
+		}
+	}
+
+	srids, err := db.GetSpatialSRIDs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spatial SRIDs: %s", err)
+	}
+
+	for tableName, columns := range data {
+		for _, column := range columns {
+			if srid, ok := srids[tableName][column.ActualName]; ok {
+				srid := srid
+				column.SRID = &srid
+			}
+		}
+	}
+
+	uniqueIndexes, err := db.GetUniqueIndexes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unique indexes: %s", err)
+	}
+
+	primaryKeys, err := db.GetPrimaryKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get primary keys: %s", err)
+	}
+
 	schema := &Schema{
 		Tables: map[string]*Table{},
 	}
 
 	for k, v := range data {
+		// the databases collate mixed case names differently, so pair
+		// columns up by their normalized names instead
+		sort.SliceStable(v, func(i, j int) bool {
+			return v[i].NormalizedName < v[j].NormalizedName
+		})
+
         normalizedName := strings.ToLower(k)
 		schema.Tables[normalizedName] = &Table{
 			ActualName:    k,
             NormalizedName: normalizedName,
 			Columns: v,
+			UniqueIndexes: uniqueIndexes[k],
+			PrimaryKey:    primaryKeys[k],
 		}
 	}
 
@@ -371,27 +476,6 @@ func differenceSlices( v1 []string, v2 []string ) {
     }
 }
 
-func StaticColumnAnalysis( src, dst *Column) int {
-    switch {
-        case src.Type == dst.Type && src.MaxChars == dst.MaxChars,
-             src.Type == "integer" && dst.Type == "int",   
-             src.Type == "character varying" && (dst.Type == "varchar" || dst.Type == "text") && src.MaxChars <= dst.MaxChars,
-             src.Type == "text" && (dst.Type == "text" || dst.Type == "mediumtext" || dst.Type == "longtext") && src.MaxChars == 0 && dst.MaxChars >= 65535,
-             src.Type == "character" && dst.Type == "char" && src.MaxChars == dst.MaxChars && src.MaxChars > 0,
-             src.Type == "boolean" && dst.Type == "tinyint" && dst.MaxChars == 0,
-             src.Type == "bytea" && dst.Type == "mediumblob" && src.MaxChars == 0 && 1024 * 1024 <= dst.MaxChars:
-                return 0
-            case src.Type == "uuid" && (dst.Type == "binary" || dst.Type == "varbinary") && dst.MaxChars == 16,
-                 src.Type == "timestamp with time zone" && dst.Type == "datetime",
-                 src.Type == "timestamp without time zone" && dst.Type == "datetime",
-                 src.Type == "timestamp without time zone" && dst.Type == "timestamp":
-                return 1
-        default:
-            fmt.Printf("EVALUATE: %s(%d)  %s(%d)\n", src.Type, src.MaxChars, dst.Type, dst.MaxChars) 
-            return 2
-    }
-}
-
 func StaticSchemaAnalysis( src, dst *Schema) (bool, error) {
 
     srcKeys := MakeSliceOrderedTableNames( src.Tables )
@@ -407,9 +491,12 @@ func StaticSchemaAnalysis( src, dst *Schema) (bool, error) {
 type IncompatibleColumns struct {
     src *Column
     dst *Column
+    assessment Assessment
 }
 
-func GetIncompatibleColumns(src, dst *Table) ([]IncompatibleColumns, error) {
+// GetIncompatibleColumns returns the pairs of columns whose rows need to be
+// checked, according to rules.
+func GetIncompatibleColumns(rules TypeRules, src, dst *Table) ([]IncompatibleColumns, error) {
 	var incompatibleColumns []IncompatibleColumns
 	for _, dstColumn := range dst.Columns {
 		_, srcColumn, err := src.GetColumn(dstColumn)
@@ -417,10 +504,11 @@ func GetIncompatibleColumns(src, dst *Table) ([]IncompatibleColumns, error) {
 			return nil, fmt.Errorf("failed to find column '%s/%s' in source schema: %s", dst.ActualName, dstColumn.ActualName, err)
 		}
 
-		if dstColumn.Incompatible(srcColumn) {
+		if assessment := rules.Assess(srcColumn, dstColumn); assessment.Checked() {
             var columnPair = IncompatibleColumns {
                 src: srcColumn,
                 dst: dstColumn,
+                assessment: assessment,
             }
             
 			incompatibleColumns = append(incompatibleColumns, columnPair)
@@ -430,95 +518,214 @@ func GetIncompatibleColumns(src, dst *Table) ([]IncompatibleColumns, error) {
 	return incompatibleColumns, nil
 }
 
-func GetIncompatibleRowIDs(db DB, src, dst *Table, debug map[string]bool) ([]int, error) {
-	columns, err := GetIncompatibleColumns(src, dst)
+// GetSchemaProblems describes the differences between the src and dst
+// tables that no change to the data can overcome: columns missing from
+// either side, and pairs of columns whose types are incompatible. Rows can
+// be checked only when every dst column is found in the src table.
+func GetSchemaProblems(rules TypeRules, src, dst *Table) (problems []string, checkable bool) {
+	checkable = true
+	for _, dstColumn := range dst.Columns {
+		_, srcColumn, err := src.GetColumn(dstColumn)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("column %s is missing from the source", dstColumn.ActualName))
+			checkable = false
+			continue
+		}
+
+		if assessment := rules.Assess(srcColumn, dstColumn); assessment.Verdict == VerdictIncompatible {
+			problems = append(problems, fmt.Sprintf("column %s cannot be converted from %s to %s: %s",
+				dstColumn.ActualName, srcColumn.Type, dstColumn.Type, assessment.Reason))
+		}
+	}
+
+	for _, srcColumn := range src.Columns {
+		if !dst.HasColumn(srcColumn) {
+			problems = append(problems, fmt.Sprintf("column %s is missing from the destination", srcColumn.ActualName))
+		}
+	}
+
+	return problems, checkable
+}
+
+// buildTableChecks returns the checks flagging rows of the src table that
+// cannot be stored in the dst table.
+func buildTableChecks(db DB, conversions *Conversions, src, dst *Table) (rowChecks, error) {
+	rules, err := conversions.TypeRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed loading type rules: %s", err)
+	}
+
+	columns, err := GetIncompatibleColumns(rules, src, dst)
 	if err != nil {
 		return nil, fmt.Errorf("failed getting incompatible columns: %s", err)
 	}
 
-	if columns == nil {
-		return nil, nil
+	checks := buildRowChecks(db, conversions, columns)
+	checks = append(checks, nullChecks(db, conversions, src, dst)...)
+	checks = append(checks, outOfRangeChecks(db, conversions, src, dst)...)
+
+	return checks, nil
+}
+
+// GetIncompatibleRows finds the rows of the src table that cannot be stored
+// in the dst table, by id when withIDs is set, along with findings telling
+// which columns fail which rules.
+func GetIncompatibleRows(db DB, conversions *Conversions, src, dst *Table, withIDs bool, debug map[string]bool) ([]int, int64, []ColumnFinding, error) {
+	checks, err := buildTableChecks(db, conversions, src, dst)
+	if err != nil {
+		return nil, 0, nil, err
 	}
 
-    // We want to compare the source column to the destination length
-	limits := make([]string, len(columns))
-	for i, column := range columns {
-		limits[i] = fmt.Sprintf("LENGTH(%s) > %d", column.src.ActualName, column.dst.MaxChars)
+	if len(checks) == 0 {
+		return nil, 0, nil, nil
 	}
 
-	stmt := fmt.Sprintf("SELECT id FROM %s WHERE %s", src.ActualName, strings.Join(limits, " OR "))
-    if debug["sql"] {
-        fmt.Println("DEBUG GetIncompatibleRowIDs SQL:", stmt)
-    }
+	var idColumn *Column
+	if withIDs {
+		_, idColumn, _ = src.GetColumn(&IDColumn)
+	}
 
-	rows, err := db.DB().Query(stmt)
-	if err != nil {
-		return nil, fmt.Errorf("failed getting incompatible row ids: %s", err)
+	// without ids to report, rows failing SQL conditions are only counted
+	if idColumn == nil && checks.countable() {
+		count, findings, err := countIncompatibleRows(db, src, checks, debug)
+		return nil, count, findings, err
 	}
 
 	var rowIDs []int
-	for rows.Next() {
-		var id int
-		if err := rows.Scan(&id); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %s", err)
+	var count int64
+	var findings findingsCollector
+	err = eachIncompatibleRow(db, src, idColumn, checks, debug, func(key interface{}, failed []failedCheck) error {
+		if idColumn != nil {
+			id, ok := key.(int64)
+			if !ok {
+				return fmt.Errorf("unexpected id type %T", key)
+			}
+			rowIDs = append(rowIDs, int(id))
 		}
-		rowIDs = append(rowIDs, id)
+		count++
+		findings.add(key, failed)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, nil, err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
+	return rowIDs, count, findings.findings, nil
+}
 
-	if err := rows.Close(); err != nil {
-		return nil, err
+func GetIncompatibleRowIDs(db DB, conversions *Conversions, src, dst *Table, debug map[string]bool) ([]int, error) {
+	rowIDs, _, _, err := GetIncompatibleRows(db, conversions, src, dst, true, debug)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting incompatible row ids: %s", err)
 	}
 
 	return rowIDs, nil
 }
 
-func GetIncompatibleRowCount(db DB, src, dst *Table, debug map[string]bool) (int64, error) {
-	columns, err := GetIncompatibleColumns(src, dst)
+func GetIncompatibleRowCount(db DB, conversions *Conversions, src, dst *Table, debug map[string]bool) (int64, error) {
+	_, count, _, err := GetIncompatibleRows(db, conversions, src, dst, false, debug)
 	if err != nil {
-		return 0, fmt.Errorf("failed getting incompatible columns: %s", err)
+		return 0, err
 	}
 
-	if columns == nil {
-		return 0, nil
-	}
+	return count, nil
+}
+
+// NormalizeTimes replaces the precise PostgreSQL times of a scanned row with
+// times of the precision each destination column can hold.
+func NormalizeTimes(dst DB, dstTable *Table, scanArgs []interface{}, debug map[string]bool) {
+	for i := range scanArgs {
+		arg := scanArgs[i]
+		iface, ok := arg.(*interface{})
+		if !ok {
+			log.Fatalf("received unexpected type as scanArg: %T (should be *interface{})", arg)
+		}
 
-	limits := make([]string, len(columns))
-	for i, column := range columns {
-		limits[i] = fmt.Sprintf("length(%s) > %d", column.src.ActualName, column.dst.MaxChars)
+		if t1, ok := (*iface).(time.Time); ok {
+			if debug["datetime"] {
+				fmt.Println("DEBUG BEFORE NormalizeTime", t1)
+			}
+			var timeArg interface{} = dst.NormalizeTime(t1, dstTable.Columns[i])
+			scanArgs[i] = &timeArg
+			if debug["datetime"] {
+				fmt.Println("DEBUG AFTER  NormalizeTime", t1, "scanArg ", i, timeArg)
+			}
+		}
 	}
+}
+
+// EachMissingRow calls f with each row of table that dstTable holds no
+// matching row for, and with the *OutOfRangeError of rows holding a value
+// outside the range of its destination column.
+func EachMissingRow(src, dst DB, conversions *Conversions, table *Table, dstTable *Table, debug map[string]bool, f func(scanArgs []interface{}, outOfRange error)) error {
+	colVals, params := comparisonClauses(dst, conversions, table, dstTable)
 
-	stmt := fmt.Sprintf("SELECT count(1) FROM %s WHERE %s", src.ActualName, strings.Join(limits, " OR "))
+	stmt := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE %s)`, dst.QuoteTable(dstTable.ActualName), strings.Join(colVals, " AND "))
     if debug["sql"] {
-        fmt.Println("DEBUG GetIncompatibleRowCount SQL:", stmt)
+        fmt.Println("DEBUG SQL:", stmt)
     }
-
-	var count int64
-	err = db.DB().QueryRow(stmt).Scan(&count)
+    //fmt.Printf( "DEBUG DESTINATION: \n%s\n", stmt)
+	preparedStmt, err := dst.DB().Prepare(stmt)
 	if err != nil {
-		return 0, err
+		return fmt.Errorf("failed to prepare statement: %s", err)
 	}
+	defer preparedStmt.Close()
 
-	return count, nil
+	var exists bool
+	return eachSourceRow(src, dst, conversions, table, dstTable, debug, func(scanArgs, _ []interface{}, outOfRange error) error {
+		// determine if the row exists in dst
+		if err := preparedStmt.QueryRow(bindParams(scanArgs, params)...).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check if row exists: %s", err)
+		}
+
+		if !exists {
+			f(scanArgs, outOfRange)
+		}
+		return nil
+	})
 }
 
-func EachMissingRow(src, dst DB, table *Table, dstTable *Table, debug map[string]bool, f func([]interface{})) error {
+// comparisonClauses returns the clauses matching each column of dstTable to
+// the value a row of table holds for it, and the index of the value bound
+// to each parameter they mark. Columns written as DEFAULT in place of NULL
+// match whatever they hold when the value is NULL.
+func comparisonClauses(dst DB, conversions *Conversions, table, dstTable *Table) (clauses []string, params []int) {
+	clauses = make([]string, len(table.Columns))
+	for i := range table.Columns {
+		clauses[i] = dst.ComparisonClause(len(params), table.Columns[i], dstTable.Columns[i])
+		params = append(params, i)
+		if conversions.writesDefaultForNull(dstTable.Columns[i]) {
+			clauses[i] = fmt.Sprintf("(%s OR %s IS NULL)", clauses[i], dst.ParameterMarker(len(params)))
+			params = append(params, i)
+		}
+	}
+	return clauses, params
+}
+
+// bindParams returns the values of a row bound to the parameters marked by
+// the clauses of comparisonClauses.
+func bindParams(values []interface{}, params []int) []interface{} {
+	args := make([]interface{}, len(params))
+	for i, index := range params {
+		args[i] = values[index]
+	}
+	return args
+}
+
+// eachSourceRow calls f with each row of table, its values normalized and
+// converted for dstTable the way they are migrated, the values as read from
+// table, and the *OutOfRangeError of rows holding values left unconverted.
+func eachSourceRow(src, dst DB, conversions *Conversions, table *Table, dstTable *Table, debug map[string]bool, f func(scanArgs, values []interface{}, outOfRange error) error) error {
 	srcColumnNamesForSelect := make([]string, len(table.Columns))
 	values := make([]interface{}, len(table.Columns))
 	scanArgs := make([]interface{}, len(table.Columns))
-	colVals := make([]string, len(table.Columns))
     for i := range table.Columns {
         // fmt.Printf( "DEBUG: Columns[%d] = %+v\n", i, table.Columns[i] )
-        srcColumnNamesForSelect[i] = src.ColumnNameForSelect(table.Columns[i].ActualName)
-		scanArgs[i] = &values[i]
-		colVals[i] = dst.ComparisonClause(i, dstTable.Columns[i].ActualName, table.Columns[i].Type)
+        srcColumnNamesForSelect[i] = conversions.SelectExpression(src, table.Columns[i], dstTable.Columns[i])
     }
 
 	// select all rows in src
-	stmt := fmt.Sprintf("SELECT %s FROM %s", strings.Join(srcColumnNamesForSelect, ","), table.ActualName)
+	stmt := fmt.Sprintf("SELECT %s FROM %s", strings.Join(srcColumnNamesForSelect, ","), src.QuoteTable(table.ActualName))
     //fmt.Printf( "DEBUG SOURCE: \n%s\n", stmt)
     if debug["sql"] {
         fmt.Println("DEBUG SQL:", stmt)
@@ -528,41 +735,22 @@ func EachMissingRow(src, dst DB, table *Table, dstTable *Table, debug map[string
 	if err != nil {
 		return fmt.Errorf("failed to select rows: %s", err)
 	}
+	defer rows.Close()
 
-	stmt = fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE %s)`, dstTable.ActualName, strings.Join(colVals, " AND "))
-    if debug["sql"] {
-        fmt.Println("DEBUG SQL:", stmt)
-    }
-    //fmt.Printf( "DEBUG DESTINATION: \n%s\n", stmt)
-	preparedStmt, err := dst.DB().Prepare(stmt)
-	if err != nil {
-		return fmt.Errorf("failed to prepare statement: %s", err)
-	}
-
-	var exists bool
 	for rows.Next() {
+		// normalizing and converting replace the scan args of the last row
+		for i := range scanArgs {
+			scanArgs[i] = &values[i]
+		}
 		if err = rows.Scan(scanArgs...); err != nil {
 			return fmt.Errorf("failed to scan row: %s", err)
 		}
 
-		for i := range scanArgs {
-			arg := scanArgs[i]
-			iface, ok := arg.(*interface{})
-			if !ok {
-				log.Fatalf("received unexpected type as scanArg: %T (should be *interface{})", arg)
-			}
+		NormalizeTimes(dst, dstTable, scanArgs, debug)
 
-			// replace the precise PostgreSQL time with a less precise MySQL-compatible time
-			if t1, ok := (*iface).(time.Time); ok {
-                if debug["datetime"] {
-                    fmt.Println( "DEBUG BEFORE NormalizeTime", t1)
-                }
-				var timeArg interface{} = dst.NormalizeTime(t1)
-				scanArgs[i] = &timeArg
-                if debug["datetime"] {
-                    fmt.Println( "DEBUG AFTER  NormalizeTime", t1, "scanArg ", i , timeArg)
-                }
-			}
+		outOfRange := conversions.ConvertScanArgs(table, dstTable, scanArgs)
+		if _, ok := outOfRange.(*OutOfRangeError); outOfRange != nil && !ok {
+			return outOfRange
 		}
         if debug["data"] {
             for i := range scanArgs {
@@ -576,18 +764,80 @@ func EachMissingRow(src, dst DB, table *Table, dstTable *Table, debug map[string
             fmt.Printf("\n")
         }
 
-		// determine if the row exists in dst
-		if err = preparedStmt.QueryRow(scanArgs...).Scan(&exists); err != nil {
-			return fmt.Errorf("failed to check if row exists: %s", err)
+		if err = f(scanArgs, values, outOfRange); err != nil {
+			return err
 		}
+	}
 
-		if !exists {
-			f(scanArgs)
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed iterating through rows: %s", err)
+	}
+
+	if err = rows.Close(); err != nil {
+		return fmt.Errorf("failed closing rows: %s", err)
+	}
+
+	return nil
+}
+
+// EachExtraRow calls f with the id of each row of dstTable whose id is not
+// in table. The ids of table are held in memory while the ids of dstTable
+// are read. ok is false when the tables have no id to match rows by.
+func EachExtraRow(src, dst DB, table *Table, dstTable *Table, debug map[string]bool, f func(id string)) (ok bool, err error) {
+	_, srcID, err := table.GetColumn(&IDColumn)
+	if err != nil {
+		return false, nil
+	}
+	_, dstID, err := dstTable.GetColumn(&IDColumn)
+	if err != nil {
+		return false, nil
+	}
+
+	ids := map[string]bool{}
+	err = eachKey(src, table, srcID, debug, func(id string) {
+		ids[id] = true
+	})
+	if err != nil {
+		return false, err
+	}
+
+	err = eachKey(dst, dstTable, dstID, debug, func(id string) {
+		if !ids[id] {
+			f(id)
 		}
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// eachKey calls f with the id of each row of table, spelled the way
+// ColIDToString spells source ids.
+func eachKey(db DB, table *Table, id *Column, debug map[string]bool, f func(id string)) error {
+	stmt := fmt.Sprintf("SELECT %s FROM %s", columnExpression(db, id), db.QuoteTable(table.ActualName))
+	if debug["sql"] {
+		fmt.Println("DEBUG SQL:", stmt)
+	}
+
+	rows, err := db.DB().Query(stmt)
+	if err != nil {
+		return fmt.Errorf("failed to select ids: %s", err)
+	}
+	defer rows.Close()
+
+	var value interface{}
+	for rows.Next() {
+		if err = rows.Scan(&value); err != nil {
+			return fmt.Errorf("failed to scan id: %s", err)
+		}
+
+		f(keyString(value, id))
 	}
 
 	if err = rows.Err(); err != nil {
-		return fmt.Errorf("failed iterating through rows: %s", err)
+		return fmt.Errorf("failed iterating through ids: %s", err)
 	}
 
 	if err = rows.Close(); err != nil {
@@ -596,3 +846,28 @@ func EachMissingRow(src, dst DB, table *Table, dstTable *Table, debug map[string
 
 	return nil
 }
+
+// keyString spells a value of the id column the way ColIDToString spells
+// source ids.
+func keyString(value interface{}, id *Column) string {
+	// mysql returns the text of numbers and strings as bytes, which only
+	// binary ids, such as uuids, should be read as
+	if b, ok := value.([]byte); ok && !IsBinaryType(id.Type) {
+		value = string(b)
+	}
+	return ColIDToString(value)
+}
+
+// CountRows returns the number of rows of table.
+func CountRows(db DB, table *Table, debug map[string]bool) (int64, error) {
+	stmt := fmt.Sprintf("SELECT count(1) FROM %s", db.QuoteTable(table.ActualName))
+	if debug["sql"] {
+		fmt.Println("DEBUG SQL:", stmt)
+	}
+
+	var count int64
+	if err := db.DB().QueryRow(stmt).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count rows: %s", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,363 @@
+package pg2mysql
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VerifyMethodDiff joins the rows of tables with a primary key or an id to
+// the destination rows with the same key, and reports each column whose
+// values differ.
+const VerifyMethodDiff = "diff"
+
+// Explanations of column differences that the migration is expected to
+// cause.
+const (
+	ExplanationPrecision  = "precision loss"
+	ExplanationTimeZone   = "time zone shift"
+	ExplanationTruncation = "truncation"
+)
+
+// ColumnDiff is a column whose value differs between a source row and the
+// destination row with the same key. Source is the value the source row
+// converts to for the destination, and Explanation is set when the
+// difference is one the migration is expected to cause.
+type ColumnDiff struct {
+	ID          string `json:"id" yaml:"id"`
+	ColumnName  string `json:"column_name" yaml:"column_name"`
+	Source      string `json:"source" yaml:"source"`
+	Destination string `json:"destination" yaml:"destination"`
+	Explanation string `json:"explanation,omitempty" yaml:"explanation,omitempty"`
+}
+
+// Explainable reports whether the difference is one the migration is
+// expected to cause.
+func (d ColumnDiff) Explainable() bool {
+	return d.Explanation != ""
+}
+
+// EachRowDiff calls f with the key of each row of table that differs from
+// the row of dstTable with the same key: with missing set when there is no
+// such row, or with the columns whose values differ otherwise. Rows are
+// joined on the primary key of table, or on its id when it declares none.
+// Columns are compared the way EachMissingRow compares them. ok is false
+// when the tables have no key to join rows by.
+func EachRowDiff(src, dst DB, conversions *Conversions, table *Table, dstTable *Table, debug map[string]bool, f func(id string, missing bool, diffs []ColumnDiff)) (ok bool, err error) {
+	indexes, _, _, ok := keyColumns(table, dstTable)
+	if !ok {
+		return false, nil
+	}
+
+	location, err := conversions.Location()
+	if err != nil {
+		return false, fmt.Errorf("invalid time_zone: %s", err)
+	}
+
+	matches, params := comparisonClauses(dst, conversions, table, dstTable)
+	dstColumnNames := make([]string, len(dstTable.Columns))
+	for i := range table.Columns {
+		matches[i] = fmt.Sprintf("CASE WHEN %s THEN 1 ELSE 0 END", matches[i])
+		dstColumnNames[i] = dst.ColumnNameForSelect(dstTable.Columns[i].ActualName)
+	}
+
+	// the key is passed again after the values, as mysql markers are positional
+	keyClauses := make([]string, len(indexes))
+	for i, index := range indexes {
+		keyClauses[i] = dst.ComparisonClause(len(params)+i, table.Columns[index], dstTable.Columns[index])
+	}
+	stmt := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s",
+		strings.Join(matches, ", "), strings.Join(dstColumnNames, ", "), dst.QuoteTable(dstTable.ActualName),
+		strings.Join(keyClauses, " AND "))
+	if debug["sql"] {
+		fmt.Println("DEBUG SQL:", stmt)
+	}
+	preparedStmt, err := dst.DB().Prepare(stmt)
+	if err != nil {
+		return false, fmt.Errorf("failed to prepare statement: %s", err)
+	}
+	defer preparedStmt.Close()
+
+	// values outside the range of their column are compared unconverted
+	err = eachSourceRow(src, dst, conversions, table, dstTable, debug, func(scanArgs, values []interface{}, _ error) error {
+		srcValues := make([]interface{}, len(scanArgs))
+		for i := range scanArgs {
+			srcValues[i] = *scanArgs[i].(*interface{})
+		}
+		id := rowKeyID(srcValues, indexes)
+
+		matched, dstValues, found, err := closestRow(preparedStmt, append(bindParams(scanArgs, params), bindParams(scanArgs, indexes)...), len(table.Columns))
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			f(id, true, nil)
+			return nil
+		}
+
+		var diffs []ColumnDiff
+		for i, match := range matched {
+			if match {
+				continue
+			}
+			dstColumn := dstTable.Columns[i]
+			// the value as read, converted but not cut to the precision of dstColumn
+			precise, err := conversions.ConvertValue(table.Columns[i], dstColumn, values[i])
+			if err != nil {
+				precise = srcValues[i]
+			}
+			diffs = append(diffs, ColumnDiff{
+				ID:          id,
+				ColumnName:  dstColumn.ActualName,
+				Source:      diffText(srcValues[i], dstColumn),
+				Destination: diffText(dstValues[i], dstColumn),
+				Explanation: explainDifference(table.Columns[i], dstColumn, precise, srcValues[i], dstValues[i], location),
+			})
+		}
+		if len(diffs) > 0 {
+			f(id, false, diffs)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// rowKeyID returns the key of a source row as reported: the values of the
+// key columns at indexes, separated by commas when there are several.
+func rowKeyID(values []interface{}, indexes []int) string {
+	if len(indexes) == 1 {
+		return ColIDToString(values[indexes[0]])
+	}
+
+	parts := make([]string, len(indexes))
+	for i, index := range indexes {
+		if values[index] == nil {
+			parts[i] = "NULL"
+			continue
+		}
+		parts[i] = ColIDToString(values[index])
+	}
+	return strings.Join(parts, ",")
+}
+
+// closestRow returns the destination row, of those stmt selects, matching
+// the most columns: which of its columns match, and its values. found is
+// false when stmt selects no row.
+func closestRow(stmt *sql.Stmt, args []interface{}, columns int) (matched []bool, values []interface{}, found bool, err error) {
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to select destination row: %s", err)
+	}
+	defer rows.Close()
+
+	best := -1
+	for rows.Next() {
+		flags := make([]interface{}, columns)
+		rowValues := make([]interface{}, columns)
+		dest := make([]interface{}, 0, 2*columns)
+		for i := range flags {
+			dest = append(dest, &flags[i])
+		}
+		for i := range rowValues {
+			dest = append(dest, &rowValues[i])
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, nil, false, fmt.Errorf("failed to scan destination row: %s", err)
+		}
+
+		rowMatched := make([]bool, columns)
+		var count int
+		for i, flag := range flags {
+			if rowMatched[i] = isTrue(flag); rowMatched[i] {
+				count++
+			}
+		}
+		if count > best {
+			best, matched, values = count, rowMatched, rowValues
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, false, fmt.Errorf("failed iterating through destination rows: %s", err)
+	}
+
+	return matched, values, best >= 0, rows.Close()
+}
+
+// diffText formats a value of a column, or a source value converted for
+// it, for a column diff. Bytes of binary columns are spelled in hex, or as
+// a uuid when there are 16 of them.
+func diffText(value interface{}, column *Column) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999 -07:00")
+	case []byte:
+		if IsBinaryType(column.Type) || strings.HasSuffix(column.Type, "blob") {
+			if len(v) == 16 {
+				return ColIDToString(v)
+			}
+			return hex.EncodeToString(v)
+		}
+		return string(v)
+	}
+	return formatValue(value)
+}
+
+// explainDifference returns the explanation of a difference between a
+// source value of the src column converted for dst and the dst value, or ""
+// when it is not one the migration is expected to cause: times whose
+// precise value, the source value before it was cut to the precision of
+// dst, is apart by less than that precision, instants of timestamps with
+// time zone apart by the offset of location, as when written in another
+// zone, decimals and floats apart by less than the precision of dst, and
+// text dst holds the start of. Integers are never explained.
+func explainDifference(src, dst *Column, preciseValue, srcValue, dstValue interface{}, location *time.Location) string {
+	if srcTime, ok := srcValue.(time.Time); ok {
+		dstTime, ok := dstValue.(time.Time)
+		if !ok {
+			return ""
+		}
+		if preciseTime, ok := preciseValue.(time.Time); ok {
+			d := preciseTime.Sub(dstTime)
+			if d < 0 {
+				d = -d
+			}
+			if d > 0 && d < FractionalSecondsUnit(dst.DatetimePrecision) {
+				return ExplanationPrecision
+			}
+		}
+		d := srcTime.Sub(dstTime)
+		if d < 0 {
+			d = -d
+		}
+		if src.Type == "timestamp with time zone" && location != nil {
+			_, offset := srcTime.In(location).Zone()
+			if offset < 0 {
+				offset = -offset
+			}
+			if offset != 0 && d == time.Duration(offset)*time.Second {
+				return ExplanationTimeZone
+			}
+		}
+		return ""
+	}
+
+	if srcValue == nil || dstValue == nil {
+		return ""
+	}
+	srcText, dstText := formatValue(srcValue), formatValue(dstValue)
+
+	if IsDecimalType(dst.Type) || isFloatType(dst.Type) {
+		srcNumber, err := strconv.ParseFloat(srcText, 64)
+		if err != nil {
+			return ""
+		}
+		dstNumber, err := strconv.ParseFloat(dstText, 64)
+		if err != nil {
+			return ""
+		}
+
+		tolerance := math.Abs(srcNumber) * 1e-6
+		if IsDecimalType(dst.Type) {
+			tolerance = math.Pow(10, -float64(dst.NumericScale)) / 2
+		}
+		if math.Abs(srcNumber-dstNumber) <= tolerance {
+			return ExplanationPrecision
+		}
+		return ""
+	}
+
+	if IsTextType(dst.Type) && len(dstText) < len(srcText) && strings.HasPrefix(srcText, dstText) {
+		return ExplanationTruncation
+	}
+	return ""
+}
+
+// isFloatType reports whether a data type of either database holds
+// floating point numbers.
+func isFloatType(dataType string) bool {
+	switch dataType {
+	case "float", "double", "real", "double precision":
+		return true
+	}
+	return false
+}
+
+// DiffWriter is a VerifierWatcher writing the column diffs it is told of
+// to W, one json document per line, and passing every event on to Watcher,
+// if set.
+type DiffWriter struct {
+	W       io.Writer
+	Watcher VerifierWatcher
+	err     error
+}
+
+func NewDiffWriter(w io.Writer) *DiffWriter {
+	return &DiffWriter{W: w}
+}
+
+// Err returns the first error writing a diff, if any.
+func (d *DiffWriter) Err() error {
+	return d.err
+}
+
+func (d *DiffWriter) TableVerificationDidStart(tableName string) {
+	if d.Watcher != nil {
+		d.Watcher.TableVerificationDidStart(tableName)
+	}
+}
+
+func (d *DiffWriter) TableVerificationDidFinish(tableName string, missingRows int64, missingIDs []string, extraRows int64, extraIDs []string) {
+	if d.Watcher != nil {
+		d.Watcher.TableVerificationDidFinish(tableName, missingRows, missingIDs, extraRows, extraIDs)
+	}
+}
+
+func (d *DiffWriter) TableVerificationDidFinishWithError(tableName string, err error) {
+	if d.Watcher != nil {
+		d.Watcher.TableVerificationDidFinishWithError(tableName, err)
+	}
+}
+
+func (d *DiffWriter) TableVerificationDidFindSchemaProblems(tableName string, problems []string) {
+	if d.Watcher != nil {
+		d.Watcher.TableVerificationDidFindSchemaProblems(tableName, problems)
+	}
+}
+
+func (d *DiffWriter) TableVerificationDidFindDifferences(tableName string, missingIDs, extraIDs, changedIDs []string) {
+	if d.Watcher != nil {
+		d.Watcher.TableVerificationDidFindDifferences(tableName, missingIDs, extraIDs, changedIDs)
+	}
+}
+
+func (d *DiffWriter) TableVerificationDidFindColumnDiffs(tableName string, diffs []ColumnDiff) {
+	encoder := json.NewEncoder(d.W)
+	for _, diff := range diffs {
+		if d.err != nil {
+			break
+		}
+		if err := encoder.Encode(struct {
+			TableName string `json:"table_name"`
+			ColumnDiff
+		}{tableName, diff}); err != nil {
+			d.err = fmt.Errorf("failed to write diff: %s", err)
+		}
+	}
+	if d.Watcher != nil {
+		d.Watcher.TableVerificationDidFindColumnDiffs(tableName, diffs)
+	}
+}
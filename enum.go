@@ -0,0 +1,80 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+func IsEnumType(c *Column) bool {
+	return len(c.EnumLabels) > 0
+}
+
+// ParseEnumColumnType returns the labels of a MySQL enum column from its
+// column_type, e.g. enum('a','b').
+func ParseEnumColumnType(columnType string) []string {
+	if !strings.HasPrefix(columnType, "enum(") || !strings.HasSuffix(columnType, ")") {
+		return nil
+	}
+	definition := columnType[len("enum(") : len(columnType)-1]
+
+	var labels []string
+	var label strings.Builder
+	var quoted bool
+	for i := 0; i < len(definition); i++ {
+		c := definition[i]
+		switch {
+		case c == '\'' && quoted && i+1 < len(definition) && definition[i+1] == '\'':
+			label.WriteByte(c)
+			i++
+		case c == '\'':
+			quoted = !quoted
+			if !quoted {
+				labels = append(labels, label.String())
+				label.Reset()
+			}
+		case quoted:
+			label.WriteByte(c)
+		}
+	}
+
+	return labels
+}
+
+// EnumLabel returns the destination label for a source enum label, applying
+// any rename configured for the source enum type.
+func (c *Conversions) EnumLabel(src *Column, label string) string {
+	if renamed, ok := c.EnumLabels[src.UDTName][label]; ok {
+		return renamed
+	}
+	return label
+}
+
+// UnmappedEnumLabels returns the labels of the src enum that, once renamed,
+// do not exist in the dst enum.
+func (c *Conversions) UnmappedEnumLabels(src, dst *Column) []string {
+	dstLabels := make(map[string]bool, len(dst.EnumLabels))
+	for _, label := range dst.EnumLabels {
+		dstLabels[label] = true
+	}
+
+	var unmapped []string
+	for _, label := range src.EnumLabels {
+		if !dstLabels[c.EnumLabel(src, label)] {
+			unmapped = append(unmapped, label)
+		}
+	}
+
+	return unmapped
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.Replace(value, "'", "''", -1) + "'"
+}
+
+func enumLabelCondition(db DB, column *Column, labels []string) string {
+	quoted := make([]string, len(labels))
+	for i, label := range labels {
+		quoted[i] = quoteLiteral(label)
+	}
+	return fmt.Sprintf("CAST(%s AS TEXT) IN (%s)", columnExpression(db, column), strings.Join(quoted, ","))
+}
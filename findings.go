@@ -0,0 +1,108 @@
+package pg2mysql
+
+import (
+	"unicode/utf8"
+)
+
+// Rules a column finding can report.
+const (
+	RuleLength      = "length"
+	RuleCharset     = "charset"
+	RuleRange       = "range"
+	RulePrecision   = "precision"
+	RuleNullability = "nullability"
+	RuleJSON        = "json"
+	RuleEnum        = "enum"
+	RuleSpatial     = "spatial"
+	RuleConversion  = "conversion"
+	RuleType        = "type"
+	RuleBounds      = "bounds"
+)
+
+// MaxFindingSamples is the number of offending values a finding keeps, and
+// MaxSampleLength the number of characters each is truncated to.
+const (
+	MaxFindingSamples = 3
+	MaxSampleLength   = 40
+)
+
+// ColumnFinding describes the rows whose values of one column fail one
+// rule. MaxSize and Limit are set for rules about sizes: the largest
+// offending value observed, and the most the destination column holds,
+// in characters or in bytes as the destination measures it. Warning is
+// set for values the migration stores with a loss it accepts, such as
+// fractional seconds beyond the precision of the destination, which do
+// not make their rows incompatible.
+type ColumnFinding struct {
+	ColumnName string   `json:"column_name" yaml:"column_name"`
+	Rule       string   `json:"rule" yaml:"rule"`
+	RowIDs     []int    `json:"row_ids,omitempty" yaml:"row_ids,omitempty"`
+	RowCount   int64    `json:"row_count" yaml:"row_count"`
+	MaxSize    int64    `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+	Limit      int64    `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Samples    []string `json:"samples,omitempty" yaml:"samples,omitempty"`
+	Warning    bool     `json:"warning,omitempty" yaml:"warning,omitempty"`
+}
+
+// findingsCollector groups the failed checks of incompatible rows into
+// findings by column and rule, in the order they are first seen.
+type findingsCollector struct {
+	findings []ColumnFinding
+	index    map[string]int
+}
+
+func (c *findingsCollector) add(key interface{}, failed []failedCheck) {
+	if c.index == nil {
+		c.index = map[string]int{}
+	}
+
+	// a row failing several checks of one rule counts once
+	counted := map[int]bool{}
+	for _, f := range failed {
+		name := f.check.column.ActualName + "\x00" + f.check.rule
+		i, ok := c.index[name]
+		if !ok {
+			i = len(c.findings)
+			c.index[name] = i
+			c.findings = append(c.findings, ColumnFinding{
+				ColumnName: f.check.column.ActualName,
+				Rule:       f.check.rule,
+				Limit:      f.check.limit,
+			})
+		}
+
+		finding := &c.findings[i]
+		if f.check.size != nil {
+			if size := f.check.size(f.value); size > finding.MaxSize {
+				finding.MaxSize = size
+			}
+		}
+
+		if counted[i] {
+			continue
+		}
+		counted[i] = true
+
+		finding.RowCount++
+		if id, ok := key.(int64); ok {
+			finding.RowIDs = append(finding.RowIDs, int(id))
+		}
+		if len(finding.Samples) < MaxFindingSamples {
+			finding.Samples = append(finding.Samples, sampleValue(f.value))
+		}
+	}
+}
+
+// sampleValue formats value for a finding, truncated to MaxSampleLength
+// characters.
+func sampleValue(value interface{}) string {
+	if value == nil {
+		return "NULL"
+	}
+
+	s := formatValue(value)
+	if utf8.RuneCountInString(s) <= MaxSampleLength {
+		return s
+	}
+	return string([]rune(s)[:MaxSampleLength]) + "..."
+}
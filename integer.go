@@ -0,0 +1,78 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// integerBits maps the integer types of both databases to their width.
+var integerBits = map[string]uint{
+	"tinyint":   8,
+	"smallint":  16,
+	"mediumint": 24,
+	"int":       32,
+	"integer":   32,
+	"bigint":    64,
+}
+
+// IsIntegerType reports whether a MySQL data type holds integers.
+func IsIntegerType(dataType string) bool {
+	switch dataType {
+	case "tinyint", "smallint", "mediumint", "int", "bigint":
+		return true
+	}
+	return false
+}
+
+// IsUnsigned reports whether a MySQL column is declared UNSIGNED.
+func IsUnsigned(c *Column) bool {
+	return strings.Contains(c.ColumnType, "unsigned")
+}
+
+// IntegerRange returns the smallest and largest value of an integer
+// column, or nils when the column is not an integer column.
+func IntegerRange(c *Column) (*big.Int, *big.Int) {
+	bits, ok := integerBits[c.Type]
+	if !ok {
+		return nil, nil
+	}
+
+	if IsUnsigned(c) {
+		max := new(big.Int).Lsh(big.NewInt(1), bits)
+		return big.NewInt(0), max.Sub(max, big.NewInt(1))
+	}
+
+	max := new(big.Int).Lsh(big.NewInt(1), bits-1)
+	min := new(big.Int).Neg(max)
+	return min, max.Sub(max, big.NewInt(1))
+}
+
+// IntegerFits reports whether every value the src column can hold fits the
+// range of the dst integer column.
+func IntegerFits(src, dst *Column) bool {
+	srcMin, srcMax := IntegerRange(src)
+	dstMin, dstMax := IntegerRange(dst)
+	if srcMin == nil || dstMin == nil {
+		return false
+	}
+	return srcMin.Cmp(dstMin) >= 0 && srcMax.Cmp(dstMax) <= 0
+}
+
+// integerRangeConditions returns SQL predicates selecting rows whose values
+// fall outside the range of the dst integer column, for the ends of the
+// range the src column can exceed.
+func integerRangeConditions(db DB, src, dst *Column) []string {
+	column := columnExpression(db, src)
+	srcMin, srcMax := IntegerRange(src)
+	dstMin, dstMax := IntegerRange(dst)
+
+	var conditions []string
+	if srcMin == nil || srcMin.Cmp(dstMin) < 0 {
+		conditions = append(conditions, fmt.Sprintf("%s < %s", column, dstMin))
+	}
+	if srcMax == nil || srcMax.Cmp(dstMax) > 0 {
+		conditions = append(conditions, fmt.Sprintf("%s > %s", column, dstMax))
+	}
+	return conditions
+}
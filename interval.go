@@ -0,0 +1,104 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// MySQLMaxTimeSeconds is the largest magnitude, 838:59:59, of a MySQL TIME.
+const MySQLMaxTimeSeconds = 838*3600 + 59*60 + 59
+
+// convertsInterval reports whether an interval column is converted to a
+// number of seconds or a MySQL TIME for the dst column, rather than copied
+// as text.
+func convertsInterval(src, dst *Column) bool {
+	return src.Type == "interval" && (IsIntegerType(dst.Type) || IsDecimalType(dst.Type) || dst.Type == "time")
+}
+
+// ConvertInterval converts an interval, as a decimal number of seconds,
+// into the representation of the dst column: whole seconds for integer
+// columns, seconds with a fraction for decimal columns or a duration for
+// TIME columns. Months count as 30 days and years as 365.25 days, the way
+// PostgreSQL computes the epoch of an interval. Intervals the dst column
+// cannot hold exactly are an error.
+func ConvertInterval(seconds string, dst *Column) (interface{}, error) {
+	r, ok := new(big.Rat).SetString(seconds)
+	if !ok {
+		return nil, fmt.Errorf("unexpected interval of %q seconds", seconds)
+	}
+
+	switch {
+	case IsIntegerType(dst.Type):
+		if !r.IsInt() {
+			return nil, fmt.Errorf("interval of %s seconds has fractional seconds", seconds)
+		}
+		if !r.Num().IsInt64() {
+			return nil, fmt.Errorf("interval of %s seconds is out of range", seconds)
+		}
+		return r.Num().Int64(), nil
+
+	case IsDecimalType(dst.Type):
+		scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(dst.NumericScale), nil))
+		if !new(big.Rat).Mul(r, scale).IsInt() {
+			return nil, fmt.Errorf("interval of %s seconds has more than %d fractional digits", seconds, dst.NumericScale)
+		}
+		if dst.NumericPrecision > 0 {
+			limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(dst.NumericPrecision-dst.NumericScale), nil)
+			if new(big.Rat).Abs(r).Cmp(new(big.Rat).SetInt(limit)) >= 0 {
+				return nil, fmt.Errorf("interval of %s seconds is out of range of decimal(%d,%d)", seconds, dst.NumericPrecision, dst.NumericScale)
+			}
+		}
+		return r.FloatString(int(dst.NumericScale)), nil
+
+	case dst.Type == "time":
+		f, _ := r.Float64()
+		return FormatMySQLTime(f, dst.DatetimePrecision)
+	}
+
+	return r.FloatString(6), nil
+}
+
+// FormatMySQLTime formats a number of seconds as a MySQL TIME literal with
+// precision fractional digits, e.g. -838:59:59 or 25:00:00.5.
+func FormatMySQLTime(seconds float64, precision int64) (string, error) {
+	sign := ""
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	scale := math.Pow10(int(precision))
+	units := int64(math.Round(seconds * scale))
+	if float64(units) > MySQLMaxTimeSeconds*scale {
+		return "", fmt.Errorf("interval of %s%v seconds exceeds the range of TIME", sign, seconds)
+	}
+
+	whole, fraction := units/int64(scale), units%int64(scale)
+	s := fmt.Sprintf("%s%02d:%02d:%02d", sign, whole/3600, whole/60%60, whole%60)
+	if precision > 0 {
+		s = fmt.Sprintf("%s.%0*d", s, precision, fraction)
+	}
+
+	return s, nil
+}
+
+func parseSeconds(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []byte:
+		return string(v), nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("unexpected interval value %T", value)
+}
+
+// intervalSecondsExpression returns SQL reading an interval as its exact
+// number of seconds: the epoch of its whole seconds, exact as a double, and
+// its microseconds added as a decimal.
+func intervalSecondsExpression(column string) string {
+	return fmt.Sprintf("ROUND(CAST(EXTRACT(EPOCH FROM date_trunc('second', %s)) AS numeric) + CAST(mod(CAST(EXTRACT(MICROSECONDS FROM %s) AS bigint), 1000000) AS numeric) / 1000000, 6)", column, column)
+}
@@ -0,0 +1,77 @@
+package pg2mysql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MySQLMaxJSONDepth is the deepest nesting MySQL accepts in a JSON document.
+const MySQLMaxJSONDepth = 100
+
+// IsHstoreType reports whether a source column holds hstore values.
+func IsHstoreType(c *Column) bool {
+	return c.Type == "USER-DEFINED" && c.UDTName == "hstore"
+}
+
+func IsJSONType(dataType string) bool {
+	return dataType == "json" || dataType == "jsonb"
+}
+
+// IncompatibleJSON reports whether a JSON document read from PostgreSQL
+// cannot be stored in a MySQL JSON column. MySQL rejects documents larger
+// than max_allowed_packet, nested deeper than MySQLMaxJSONDepth, holding
+// numbers outside the range of a double, or containing a \u0000 character,
+// all of which the PostgreSQL json type accepts. A maxSize of 0 means no
+// size limit.
+func IncompatibleJSON(value interface{}, maxSize int64) bool {
+	var doc []byte
+	switch v := value.(type) {
+	case []byte:
+		doc = v
+	case string:
+		doc = []byte(v)
+	default:
+		return false
+	}
+
+	if maxSize > 0 && int64(len(doc)) > maxSize {
+		return true
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+
+	var depth int
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return false
+		}
+		if err != nil {
+			return true
+		}
+
+		switch t := token.(type) {
+		case json.Delim:
+			if t == '{' || t == '[' {
+				depth++
+				if depth > MySQLMaxJSONDepth {
+					return true
+				}
+			} else {
+				depth--
+			}
+		case json.Number:
+			if _, err := strconv.ParseFloat(string(t), 64); err != nil {
+				return true
+			}
+		case string:
+			if strings.ContainsRune(t, 0) {
+				return true
+			}
+		}
+	}
+}
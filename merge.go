@@ -0,0 +1,181 @@
+package pg2mysql
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// keyedDigests streams the rows of a table in key order, as the sort key of
+// each row, the key as reported and the row digest. Integer keys are read
+// in the order of their index; other keys in the byte order of their text.
+type keyedDigests struct {
+	rows    *sql.Rows
+	key     *Column
+	integer bool
+	sortKey []byte
+	number  int64
+	value   interface{}
+	digest  string
+	done    bool
+}
+
+// mergeKey holds the SQL a table's rows are ordered and reported by.
+type mergeKey struct {
+	// sort is the SQL of the key the rows are ordered by
+	sort string
+	// id is the SQL of the key as reported
+	id string
+	// column is the key column when the key has only one
+	column *Column
+	// integer is set when sort is an integer column that holds no NULLs
+	integer bool
+}
+
+func newMergeKey(db DB, texts []string, indexes []int, key []*Column, integer bool) mergeKey {
+	if integer {
+		column := columnExpression(db, key[0])
+		return mergeKey{sort: column, id: column, column: key[0], integer: true}
+	}
+
+	keyText := db.KeyText(keyTexts(texts, indexes))
+	if len(key) == 1 {
+		return mergeKey{sort: db.BinarySortKey(keyText), id: columnExpression(db, key[0]), column: key[0]}
+	}
+	return mergeKey{sort: db.BinarySortKey(keyText), id: keyText}
+}
+
+func selectKeyedDigests(db DB, table *Table, key mergeKey, texts []string, debug map[string]bool) (*keyedDigests, error) {
+	stmt := fmt.Sprintf("SELECT %s, %s, %s FROM %s ORDER BY 1",
+		key.sort, key.id, db.RowDigest(texts), db.QuoteTable(table.ActualName))
+	if debug["sql"] {
+		fmt.Println("DEBUG SQL:", stmt)
+	}
+
+	rows, err := db.DB().Query(stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select rows of %s: %s", table.ActualName, err)
+	}
+
+	k := &keyedDigests{rows: rows, key: key.column, integer: key.integer}
+	return k, k.next()
+}
+
+// next moves to the next row, setting done after the last one.
+func (k *keyedDigests) next() error {
+	if !k.rows.Next() {
+		k.done = true
+		if err := k.rows.Err(); err != nil {
+			return fmt.Errorf("failed iterating through rows: %s", err)
+		}
+		return nil
+	}
+
+	sortKey := interface{}(&k.sortKey)
+	if k.integer {
+		sortKey = &k.number
+	}
+	if err := k.rows.Scan(sortKey, &k.value, &k.digest); err != nil {
+		return fmt.Errorf("failed to scan row: %s", err)
+	}
+	return nil
+}
+
+// compare orders the current rows of k and other by their keys.
+func (k *keyedDigests) compare(other *keyedDigests) int {
+	if !k.integer {
+		return bytes.Compare(k.sortKey, other.sortKey)
+	}
+
+	switch {
+	case k.number < other.number:
+		return -1
+	case k.number > other.number:
+		return 1
+	}
+	return 0
+}
+
+func (k *keyedDigests) idString() string {
+	switch {
+	case k.integer:
+		return strconv.FormatInt(k.number, 10)
+	case k.key != nil:
+		return keyString(k.value, k.key)
+	}
+	return keyTextID(formatValue(k.value))
+}
+
+// MergeTableRows compares the rows of the src and dst tables in two
+// sequential scans: both are read in the order of their primary key, or of
+// their id when they declare none, and merged, comparing the digests of the
+// rows with the same key. Keys of one integer column are read in the order
+// of their index. Others are ordered by the UTF-8 bytes of their text rather
+// than by the collations of the databases, which keeps both sides in the
+// same order, NULLs included. ok is false when the tables have no key, or
+// columns that cannot be spelled alike on both databases.
+func MergeTableRows(src, dst DB, conversions *Conversions, srcTable, dstTable *Table, debug map[string]bool) (differences KeyDifferences, ok bool, err error) {
+	indexes, srcKey, dstKey, ok := keyColumns(srcTable, dstTable)
+	if !ok {
+		return KeyDifferences{}, false, nil
+	}
+
+	srcTexts, dstTexts, ok := checksumTexts(src, dst, conversions, srcTable, dstTable)
+	if !ok {
+		return KeyDifferences{}, false, nil
+	}
+
+	// the databases sort NULLs apart, so only keys without them are read by index
+	integer := integerKey(srcKey, dstKey) && !srcKey[0].IsNullable && !dstKey[0].IsNullable
+
+	srcRows, err := selectKeyedDigests(src, srcTable, newMergeKey(src, srcTexts, indexes, srcKey, integer), srcTexts, debug)
+	if err != nil {
+		return KeyDifferences{}, false, err
+	}
+	defer srcRows.rows.Close()
+
+	dstRows, err := selectKeyedDigests(dst, dstTable, newMergeKey(dst, dstTexts, indexes, dstKey, integer), dstTexts, debug)
+	if err != nil {
+		return KeyDifferences{}, false, err
+	}
+	defer dstRows.rows.Close()
+
+	for !srcRows.done || !dstRows.done {
+		order := 0
+		switch {
+		case dstRows.done:
+			order = -1
+		case srcRows.done:
+			order = 1
+		default:
+			order = srcRows.compare(dstRows)
+		}
+
+		switch {
+		case order < 0:
+			differences.MissingIDs = append(differences.MissingIDs, srcRows.idString())
+			err = srcRows.next()
+		case order > 0:
+			differences.ExtraIDs = append(differences.ExtraIDs, dstRows.idString())
+			err = dstRows.next()
+		default:
+			if srcRows.digest != dstRows.digest {
+				differences.ChangedIDs = append(differences.ChangedIDs, srcRows.idString())
+			}
+			if err = srcRows.next(); err == nil {
+				err = dstRows.next()
+			}
+		}
+		if err != nil {
+			return KeyDifferences{}, false, err
+		}
+	}
+
+	// keys are reported in their natural order, not the byte order of their text
+	sortIDs(differences.MissingIDs)
+	sortIDs(differences.ExtraIDs)
+	sortIDs(differences.ChangedIDs)
+
+	return differences, true, nil
+}
@@ -13,10 +13,11 @@ type Migrator interface {
 	Migrate() error
 }
 
-func NewMigrator(src, dst DB, truncateFirst bool, watcher MigratorWatcher, debug map[string]bool) Migrator {
+func NewMigrator(src, dst DB, conversions *Conversions, truncateFirst bool, watcher MigratorWatcher, debug map[string]bool) Migrator {
 	return &migrator{
 		src:           src,
 		dst:           dst,
+		conversions:   conversions,
 		truncateFirst: truncateFirst,
 		watcher:       watcher,
         debug:         debug,
@@ -25,6 +26,7 @@ func NewMigrator(src, dst DB, truncateFirst bool, watcher MigratorWatcher, debug
 
 type migrator struct {
 	src, dst      DB
+	conversions   *Conversions
 	truncateFirst bool
 	watcher       MigratorWatcher
     debug         map[string]bool
@@ -36,6 +38,10 @@ func (m *migrator) Migrate() error {
 		return fmt.Errorf("failed to build source schema: %s", err)
 	}
 
+	if err = m.conversions.SplitRangeColumns(srcSchema); err != nil {
+		return fmt.Errorf("failed to split range columns: %s", err)
+	}
+
 	dstSchema, err := BuildSchema(m.dst)
 	if err != nil {
 		return fmt.Errorf("failed to build destination schema: %s", err)
@@ -65,7 +71,7 @@ func (m *migrator) Migrate() error {
         }
 		if m.truncateFirst {
 			m.watcher.WillTruncateTable(dstTable.ActualName)
-            stmt := fmt.Sprintf("TRUNCATE TABLE %s", dstTable.ActualName)
+            stmt := fmt.Sprintf("TRUNCATE TABLE %s", m.dst.QuoteTable(dstTable.ActualName))
 
             if m.debug["sql"] {
                 fmt.Println("DEBUG SQL:", stmt)
@@ -78,32 +84,7 @@ func (m *migrator) Migrate() error {
 			m.watcher.TruncateTableDidFinish(table.ActualName)
 		}
 
-		columnNamesForInsert := make([]string, len(dstTable.Columns))
-		placeholders := make([]string, len(dstTable.Columns))
-		for i := range table.Columns {
-			columnNamesForInsert[i] = m.dst.ColumnNameForSelect(dstTable.Columns[i].ActualName)
-            if table.Columns[i].Type == "uuid" {
-                placeholders[i] = "unhex(replace(" + m.dst.ParameterMarker(i) + ",'-',''))"
-            } else {
-			    placeholders[i] = m.dst.ParameterMarker(i)
-            }
-		}
-
-
-        stmt := fmt.Sprintf(
-			"INSERT INTO %s (%s) VALUES (%s)",
-			dstTable.ActualName,
-			strings.Join(columnNamesForInsert, ","),
-			strings.Join(placeholders, ","),
-		)
-
-		preparedStmt, err := m.dst.DB().Prepare(stmt)
-
-        // omgdebug
-        if m.debug["sql"] {
-            fmt.Println("DEBUG SQL:", stmt)
-        }
-
+		preparedStmt, err := newInsertStatement(m.dst, m.conversions, table, dstTable, m.debug)
 		if err != nil {
 			return fmt.Errorf("failed creating prepared statement: %s", err)
 		}
@@ -113,13 +94,17 @@ func (m *migrator) Migrate() error {
 		m.watcher.TableMigrationDidStart(table.ActualName)
 
 		if table.HasColumn(&IDColumn) {
-			err = migrateWithIDs(m.watcher, m.src, m.dst, table, dstTable, m.debug, &recordsInserted, preparedStmt)
+			err = migrateWithIDs(m.watcher, m.src, m.dst, m.conversions, table, dstTable, m.debug, &recordsInserted, preparedStmt)
 			if err != nil {
 				return fmt.Errorf("failed migrating table with ids: %s", err)
 			}
 		} else {
-			err = EachMissingRow(m.src, m.dst, table, dstTable, m.debug, func(scanArgs []interface{}) {
-				err = insert(preparedStmt, scanArgs)
+			err = EachMissingRow(m.src, m.dst, m.conversions, table, dstTable, m.debug, func(scanArgs []interface{}, outOfRange error) {
+				if outOfRange != nil {
+					fmt.Fprintf(os.Stderr, "skipping row of %s: %s\n", table.ActualName, outOfRange)
+					return
+				}
+				err = preparedStmt.insert(scanArgs)
 				if err != nil {
                     fmt.Fprintf(os.Stderr,  "%v\n", preparedStmt  );
 					fmt.Fprintf(os.Stderr, "failed to insert into %s: %s\n", table.ActualName, err)
@@ -136,6 +121,10 @@ func (m *migrator) Migrate() error {
 		}
 
 		m.watcher.TableMigrationDidFinish(table.ActualName, recordsInserted)
+
+		if err = preparedStmt.Close(); err != nil {
+			return fmt.Errorf("failed closing prepared statements: %s", err)
+		}
 	}
 
 	return nil
@@ -145,22 +134,29 @@ func migrateWithIDs(
 	watcher MigratorWatcher,
 	src DB,
 	dst DB,
+	conversions *Conversions,
 	table *Table,
 	dstTable *Table,
     debug map[string]bool,
 	recordsInserted *int64,
-	preparedStmt *sql.Stmt,
+	preparedStmt *insertStatement,
 ) error {
 	columnNamesForSelect := make([]string, len(table.Columns))
 	values := make([]interface{}, len(table.Columns))
 	scanArgs := make([]interface{}, len(table.Columns))
 	for i := range table.Columns {
-		columnNamesForSelect[i] = table.Columns[i].ActualName
+		columnNamesForSelect[i] = conversions.SelectExpression(src, table.Columns[i], dstTable.Columns[i])
 		scanArgs[i] = &values[i]
 	}
 
+	_, srcIDColumn, _ := table.GetColumn(&IDColumn)
+	_, dstIDColumn, err := dstTable.GetColumn(&IDColumn)
+	if err != nil {
+		return fmt.Errorf("failed to find id column in destination: %s", err)
+	}
+
 	// find ids already in dst
-    stmt := fmt.Sprintf("SELECT id FROM %s", table.ActualName)
+    stmt := fmt.Sprintf("SELECT %s FROM %s", dst.QuoteIdentifier(dstIDColumn.ActualName), dst.QuoteTable(dstTable.ActualName))
     if debug["sql"] {
         fmt.Println("DEBUG SQL:", stmt)
     }
@@ -190,17 +186,17 @@ func migrateWithIDs(
 	stmt = fmt.Sprintf(
 		"SELECT %s FROM %s",
 		strings.Join(columnNamesForSelect, ","),
-		table.ActualName,
+		src.QuoteTable(table.ActualName),
 	)
 	selectArgs := make([]interface{}, 0)
 
 	if len(dstIDs) > 0 && len(dstIDs) < 65535 {
 		placeholders := make([]string, len(dstIDs))
 		for i := range dstIDs {
-			placeholders[i] = fmt.Sprintf("$%d", i+1)
+			placeholders[i] = src.ParameterMarker(i)
 		}
 
-		stmt = fmt.Sprintf("%s WHERE id NOT IN (%s)", stmt, strings.Join(placeholders, ","))
+		stmt = fmt.Sprintf("%s WHERE %s NOT IN (%s)", stmt, src.QuoteIdentifier(srcIDColumn.ActualName), strings.Join(placeholders, ","))
 		selectArgs = dstIDs
 	}
 
@@ -216,6 +212,16 @@ func migrateWithIDs(
 		if err = rows.Scan(scanArgs...); err != nil {
 			return fmt.Errorf("failed to scan row: %s", err)
 		}
+
+		NormalizeTimes(dst, dstTable, scanArgs, debug)
+
+		if err = conversions.ConvertScanArgs(table, dstTable, scanArgs); err != nil {
+			if _, ok := err.(*OutOfRangeError); ok {
+				fmt.Fprintf(os.Stderr, "skipping row of %s: %s\n", table.ActualName, err)
+				continue
+			}
+			return err
+		}
         if debug["data"] {
             for i := range scanArgs {
                 arg := scanArgs[i]
@@ -231,7 +237,7 @@ func migrateWithIDs(
             fmt.Println("DEBUG SQL: ", preparedStmt)
         }
 
-		err = insert(preparedStmt, scanArgs)
+		err = preparedStmt.insert(scanArgs)
 		if err != nil {
 			if !isPrimaryKeyError(err) {
 				fmt.Fprintf(os.Stderr, "failed to insert into %s: %s\n", table.ActualName, err)
@@ -253,6 +259,107 @@ func migrateWithIDs(
 	return nil
 }
 
+// insertStatement inserts rows into a dst table. NULLs of columns written
+// as DEFAULT are left out of the values and DEFAULT written in their place,
+// so a statement is prepared for each set of such columns rows hold NULLs in.
+type insertStatement struct {
+	db          DB
+	conversions *Conversions
+	table       *Table
+	dstTable    *Table
+	debug       map[string]bool
+	stmts       map[string]*sql.Stmt
+}
+
+// newInsertStatement prepares the statement inserting rows without NULLs in
+// columns written as DEFAULT.
+func newInsertStatement(db DB, conversions *Conversions, table, dstTable *Table, debug map[string]bool) (*insertStatement, error) {
+	s := &insertStatement{
+		db:          db,
+		conversions: conversions,
+		table:       table,
+		dstTable:    dstTable,
+		debug:       debug,
+		stmts:       map[string]*sql.Stmt{},
+	}
+
+	_, err := s.prepare(make([]bool, len(dstTable.Columns)))
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// prepare returns the statement writing DEFAULT to each column set in
+// defaulted, preparing it on first use.
+func (s *insertStatement) prepare(defaulted []bool) (*sql.Stmt, error) {
+	key := fmt.Sprint(defaulted)
+	if stmt, ok := s.stmts[key]; ok {
+		return stmt, nil
+	}
+
+	columnNamesForInsert := make([]string, len(s.dstTable.Columns))
+	placeholders := make([]string, len(s.dstTable.Columns))
+	var params int
+	for i := range s.table.Columns {
+		columnNamesForInsert[i] = s.db.ColumnNameForSelect(s.dstTable.Columns[i].ActualName)
+		if defaulted[i] {
+			placeholders[i] = "DEFAULT"
+			continue
+		}
+		placeholders[i] = s.db.ParameterForColumn(params, s.table.Columns[i], s.dstTable.Columns[i])
+		params++
+	}
+
+	stmt := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		s.db.QuoteTable(s.dstTable.ActualName),
+		strings.Join(columnNamesForInsert, ","),
+		strings.Join(placeholders, ","),
+	)
+	if s.debug["sql"] {
+		fmt.Println("DEBUG SQL:", stmt)
+	}
+
+	preparedStmt, err := s.db.DB().Prepare(stmt)
+	if err != nil {
+		return nil, err
+	}
+	s.stmts[key] = preparedStmt
+	return preparedStmt, nil
+}
+
+// insert inserts a row of scanned values, converted for the dst table.
+func (s *insertStatement) insert(scanArgs []interface{}) error {
+	defaulted := make([]bool, len(scanArgs))
+	values := make([]interface{}, 0, len(scanArgs))
+	for i, arg := range scanArgs {
+		if iface, ok := arg.(*interface{}); ok && *iface == nil && s.conversions.writesDefaultForNull(s.dstTable.Columns[i]) {
+			defaulted[i] = true
+			continue
+		}
+		values = append(values, arg)
+	}
+
+	stmt, err := s.prepare(defaulted)
+	if err != nil {
+		return fmt.Errorf("failed creating prepared statement: %s", err)
+	}
+	return insert(stmt, values)
+}
+
+// Close closes the prepared statements.
+func (s *insertStatement) Close() error {
+	var err error
+	for _, stmt := range s.stmts {
+		if closeErr := stmt.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
 func insert(stmt *sql.Stmt, values []interface{}) error {
 	result, err := stmt.Exec(values...)
 	if err != nil {
@@ -27,6 +27,8 @@ var _ = Describe("Migrator", func() {
 			"127.0.0.1",
 			3306,
 			false,
+			nil,
+			"",
 		)
 
 		err := mysql.Open()
@@ -44,7 +46,7 @@ var _ = Describe("Migrator", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		watcher = &pg2mysqlfakes.FakeMigratorWatcher{}
-		migrator = pg2mysql.NewMigrator(pg, mysql, truncateFirst, watcher)
+		migrator = pg2mysql.NewMigrator(pg, mysql, &pg2mysql.Conversions{}, truncateFirst, watcher, nil)
 	})
 
 	AfterEach(func() {
@@ -270,5 +272,588 @@ var _ = Describe("Migrator", func() {
 				Expect(truthiness).To(BeTrue())
 			})
 		})
+
+		Context("when tables and columns are named with reserved words or mixed case", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE "order" (id integer NOT NULL, "group" text NOT NULL, "user" text, "MixedCase" text)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE `order` (`id` integer NOT NULL, `group` varchar(255) NOT NULL, `user` varchar(255), `MixedCase` varchar(255))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO "order" (id, "group", "user", "MixedCase") VALUES (1, 'some-group', 'some-user', 'Some-Value')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE "order"`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE `order`")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("inserts the data into the target", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var id int
+				var group, user, mixedCase string
+
+				stmt := "SELECT `id`, `group`, `user`, `MixedCase` FROM `order`"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&id, &group, &user, &mixedCase)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(id).To(Equal(1))
+				Expect(group).To(Equal("some-group"))
+				Expect(user).To(Equal("some-user"))
+				Expect(mixedCase).To(Equal("Some-Value"))
+			})
+
+			It("does not insert rows twice", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+				err = migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var count int64
+				err = mysqlRunner.DB().QueryRow("SELECT COUNT(1) FROM `order`").Scan(&count)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(BeNumerically("==", 1))
+			})
+		})
+
+		Context("when there are json columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_json (id integer NOT NULL, doc json, bdoc jsonb)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_json (`id` integer NOT NULL, `doc` json, `bdoc` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_json (id, doc, bdoc) VALUES (1, '{"b": 2, "a": [1, "x"]}', '{"b": 2, "a": [1, "x"]}'), (2, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_json`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_json")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("inserts the documents into the target", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var matches bool
+				stmt := `SELECT doc = CAST('{"a": [1, "x"], "b": 2}' AS JSON) AND bdoc = CAST('{"a": [1, "x"], "b": 2}' AS JSON) FROM table_with_json WHERE id = 1`
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&matches)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(matches).To(BeTrue())
+
+				var isNull bool
+				err = mysqlRunner.DB().QueryRow("SELECT doc IS NULL AND bdoc IS NULL FROM table_with_json WHERE id = 2").Scan(&isNull)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isNull).To(BeTrue())
+			})
+		})
+
+		Context("when there are array columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_array (id integer NOT NULL, tags text[], nums integer[])`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_array (`id` integer NOT NULL, `tags` varchar(20), `nums` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_array (id, tags, nums) VALUES (1, '{a,"b c"}', '{{1,2},{3,NULL}}')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_array`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_array")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("converts the arrays for the destination columns", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var tags string
+				var nums string
+				err = mysqlRunner.DB().QueryRow("SELECT tags, CAST(nums AS CHAR) FROM table_with_array WHERE id = 1").Scan(&tags, &nums)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tags).To(Equal("a,b c"))
+				Expect(nums).To(Equal("[[1, 2], [3, null]]"))
+			})
+		})
+
+		Context("when there are enum columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TYPE mood AS ENUM ('sad', 'ok', 'very happy'); CREATE TABLE table_with_enum (id integer NOT NULL, mood mood)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_enum (`id` integer NOT NULL, `mood` enum('sad','ok','happy'))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_enum (id, mood) VALUES (1, 'sad'), (2, 'very happy')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_enum; DROP TYPE mood`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_enum")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("renames the labels configured", func() {
+				conversions := &pg2mysql.Conversions{
+					EnumLabels: map[string]map[string]string{
+						"mood": {"very happy": "happy"},
+					},
+				}
+				err := pg2mysql.NewMigrator(pg, mysql, conversions, truncateFirst, watcher, nil).Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var moods []string
+				rows, err := mysqlRunner.DB().Query("SELECT mood FROM table_with_enum ORDER BY id")
+				Expect(err).NotTo(HaveOccurred())
+				for rows.Next() {
+					var mood string
+					Expect(rows.Scan(&mood)).To(Succeed())
+					moods = append(moods, mood)
+				}
+				Expect(rows.Err()).NotTo(HaveOccurred())
+				Expect(moods).To(Equal([]string{"sad", "happy"}))
+			})
+		})
+
+		Context("when timestamps with time zone are migrated in UTC", func() {
+			var utcMySQL pg2mysql.DB
+
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_timestamptz (id integer NOT NULL, happened_at timestamp with time zone, logged_at timestamp without time zone)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_timestamptz (`id` integer NOT NULL, `happened_at` datetime, `logged_at` datetime)")
+				Expect(err).NotTo(HaveOccurred())
+
+				// either side of the start of daylight saving time in Europe
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_timestamptz (id, happened_at, logged_at) VALUES
+					(1, '2021-03-28 01:30:00+01', '2021-03-28 01:30:00'),
+					(2, '2021-03-28 03:30:00+02', '2021-03-28 03:30:00')`)
+				Expect(err).NotTo(HaveOccurred())
+
+				utcMySQL = pg2mysql.NewMySQLDB(
+					mysqlRunner.DBName,
+					"root",
+					"admin",
+					"127.0.0.1",
+					3306,
+					false,
+					time.UTC,
+					"",
+				)
+				err = utcMySQL.Open()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				err := utcMySQL.Close()
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`DROP TABLE table_with_timestamptz`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_timestamptz")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("stores the instants in UTC and keeps wall clocks without a zone", func() {
+				conversions := &pg2mysql.Conversions{TimeZone: "UTC"}
+				err := pg2mysql.NewMigrator(pg, utcMySQL, conversions, truncateFirst, watcher, nil).Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var happenedAt, loggedAt string
+				stmt := "SELECT CAST(happened_at AS CHAR), CAST(logged_at AS CHAR) FROM table_with_timestamptz WHERE id = 1"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&happenedAt, &loggedAt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(happenedAt).To(Equal("2021-03-28 00:30:00"))
+				Expect(loggedAt).To(Equal("2021-03-28 01:30:00"))
+
+				stmt = "SELECT CAST(happened_at AS CHAR), CAST(logged_at AS CHAR) FROM table_with_timestamptz WHERE id = 2"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&happenedAt, &loggedAt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(happenedAt).To(Equal("2021-03-28 01:30:00"))
+				Expect(loggedAt).To(Equal("2021-03-28 03:30:00"))
+			})
+		})
+
+		Context("when time columns have fractional seconds", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_fsp (id integer NOT NULL, micros timestamp(6), millis timestamp(6))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_fsp (`id` integer NOT NULL, `micros` datetime(6), `millis` datetime(3))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_fsp (id, micros, millis) VALUES
+					(1, '2021-01-01 10:00:00.123456', '2021-01-01 10:00:00.123'),
+					(2, '2021-01-01 10:00:00.5', '2021-01-01 10:00:00.123456'),
+					(3, NULL, '2021-01-01 10:00:00.000001')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_fsp`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_fsp")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the fractional seconds the destination can hold", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var micros, millis string
+				stmt := "SELECT CAST(micros AS CHAR), CAST(millis AS CHAR) FROM table_with_fsp WHERE id = 1"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&micros, &millis)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(micros).To(Equal("2021-01-01 10:00:00.123456"))
+				Expect(millis).To(Equal("2021-01-01 10:00:00.123"))
+
+				stmt = "SELECT CAST(millis AS CHAR) FROM table_with_fsp WHERE id = 2"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&millis)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(millis).To(Equal("2021-01-01 10:00:00.123"))
+			})
+		})
+
+		Context("when there are interval, time and date columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_time_types (id integer NOT NULL, duration interval, elapsed interval, opens_at time with time zone, closes_at time(6), born_on date)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_time_types (`id` integer NOT NULL, `duration` bigint, `elapsed` time, `opens_at` time, `closes_at` time(3), `born_on` date)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_time_types (id, duration, elapsed, opens_at, closes_at, born_on) VALUES
+					(1, '1 day 02:00:00', '-30 hours 15 minutes', '10:00:00+02', '18:30:00.123456', '2000-02-29')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_time_types`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_time_types")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("converts them to their MySQL representation", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var duration int64
+				var elapsed, opensAt, closesAt, bornOn string
+				stmt := "SELECT duration, CAST(elapsed AS CHAR), CAST(opens_at AS CHAR), CAST(closes_at AS CHAR), CAST(born_on AS CHAR) FROM table_with_time_types WHERE id = 1"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&duration, &elapsed, &opensAt, &closesAt, &bornOn)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(duration).To(Equal(int64(93600)))
+				Expect(elapsed).To(Equal("-29:45:00"))
+				Expect(opensAt).To(Equal("08:00:00"))
+				Expect(closesAt).To(Equal("18:30:00.123"))
+				Expect(bornOn).To(Equal("2000-02-29"))
+			})
+		})
+
+		Context("when there are network addresses and bit strings", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_network (id integer NOT NULL, address inet, network cidr, mac macaddr, flags varbit(8))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_network (`id` integer NOT NULL, `address` varbinary(16), `network` varchar(18), `mac` binary(6), `flags` bit(4))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_network (id, address, network, mac, flags) VALUES
+					(1, '192.168.0.1', '10.0.0.0/8', '08:00:2b:01:02:03', B'0101'),
+					(2, '2001:db8::1', NULL, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_network`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_network")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("packs addresses and bits like MySQL does", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var address, network, mac string
+				var flags int64
+				stmt := "SELECT INET6_NTOA(address), network, HEX(mac), flags + 0 FROM table_with_network WHERE id = 1"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&address, &network, &mac, &flags)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(address).To(Equal("192.168.0.1"))
+				Expect(network).To(Equal("10.0.0.0/8"))
+				Expect(mac).To(Equal("08002B010203"))
+				Expect(flags).To(Equal(int64(5)))
+
+				err = mysqlRunner.DB().QueryRow("SELECT INET6_NTOA(address) FROM table_with_network WHERE id = 2").Scan(&address)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(address).To(Equal("2001:db8::1"))
+			})
+		})
+
+		Context("when IPv4 addresses go into binary(16) columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_addresses (id integer NOT NULL, address inet)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_addresses (`id` integer NOT NULL, `address` binary(16))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_addresses (id, address) VALUES (1, '192.168.0.1')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_addresses`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_addresses")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("writes them mapped to IPv6 rather than padded with zeros", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var address string
+				err = mysqlRunner.DB().QueryRow("SELECT HEX(address) FROM table_with_addresses WHERE id = 1").Scan(&address)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(address).To(Equal("00000000000000000000FFFFC0A80001"))
+			})
+		})
+
+		Context("when there are geometries", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE EXTENSION IF NOT EXISTS postgis`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`CREATE TABLE table_with_geometry (id integer NOT NULL, location geometry(Point,4326), shape geometry)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_geometry (`id` integer NOT NULL, `location` point, `shape` polygon)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_geometry (id, location, shape) VALUES
+					(1, ST_GeomFromText('POINT(1 2)', 4326), ST_GeomFromText('POLYGON((0 0,1 0,1 1,0 0))', 3857))`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_geometry`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_geometry")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("copies them with their SRID", func() {
+				err := migrator.Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var location, shape string
+				var srid, shapeSRID int
+				stmt := "SELECT ST_AsText(location), ST_SRID(location), ST_AsText(shape), ST_SRID(shape) FROM table_with_geometry WHERE id = 1"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&location, &srid, &shape, &shapeSRID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(location).To(Equal("POINT(1 2)"))
+				Expect(srid).To(Equal(4326))
+				Expect(shape).To(Equal("POLYGON((0 0,1 0,1 1,0 0))"))
+				Expect(shapeSRID).To(Equal(3857))
+			})
+		})
+
+		Context("when there are ranges and hstores", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE EXTENSION IF NOT EXISTS hstore`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`CREATE TABLE table_with_ranges (id integer NOT NULL, period tstzrange, seats int4range, attrs hstore)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_ranges (`id` integer NOT NULL, `period_start` datetime, `period_end` datetime, `seats` json, `attrs` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_ranges (id, period, seats, attrs) VALUES
+					(1, '[2021-01-01 10:00:00+00,2021-01-02 10:00:00+00)', '[1,5)', 'a=>1, b=>NULL'),
+					(2, '[2021-01-01 10:00:00+00,)', 'empty', NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_ranges`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_ranges")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("splits ranges into their bounds and converts the rest to JSON", func() {
+				conversions := &pg2mysql.Conversions{
+					SplitRanges: map[string]map[string]pg2mysql.RangeColumns{
+						"table_with_ranges": {"period": {Lower: "period_start", Upper: "period_end"}},
+					},
+				}
+				err := pg2mysql.NewMigrator(pg, mysql, conversions, truncateFirst, watcher, nil).Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var periodStart, periodEnd string
+				var seats, attrs bool
+				stmt := `SELECT CAST(period_start AS CHAR), CAST(period_end AS CHAR),
+					seats = CAST('{"lower": 1, "upper": 5, "bounds": "[)"}' AS JSON),
+					attrs = CAST('{"a": "1", "b": null}' AS JSON)
+					FROM table_with_ranges WHERE id = 1`
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&periodStart, &periodEnd, &seats, &attrs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(periodStart).To(Equal("2021-01-01 10:00:00"))
+				Expect(periodEnd).To(Equal("2021-01-02 10:00:00"))
+				Expect(seats).To(BeTrue())
+				Expect(attrs).To(BeTrue())
+
+				var periodEndIsNull bool
+				stmt = `SELECT period_end IS NULL, seats = CAST('{"lower": null, "upper": null, "bounds": "empty"}' AS JSON)
+					FROM table_with_ranges WHERE id = 2`
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&periodEndIsNull, &seats)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(periodEndIsNull).To(BeTrue())
+				Expect(seats).To(BeTrue())
+			})
+		})
+
+		Context("when the connection uses utf8mb4", func() {
+			var utf8mb4MySQL pg2mysql.DB
+
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_charsets (id integer NOT NULL, name varchar(10), body text)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_charsets (`id` integer NOT NULL, `name` varchar(10) CHARACTER SET utf8, `body` tinytext CHARACTER SET utf8mb4)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_charsets (id, name, body) VALUES (1, 'plain', '😀')`)
+				Expect(err).NotTo(HaveOccurred())
+
+				utf8mb4MySQL = pg2mysql.NewMySQLDB(
+					mysqlRunner.DBName,
+					"root",
+					"admin",
+					"127.0.0.1",
+					3306,
+					false,
+					nil,
+					"utf8mb4",
+				)
+				err = utf8mb4MySQL.Open()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				err := utf8mb4MySQL.Close()
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`DROP TABLE table_with_charsets`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_charsets")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps supplementary characters in utf8mb4 columns", func() {
+				err := pg2mysql.NewMigrator(pg, utf8mb4MySQL, &pg2mysql.Conversions{}, truncateFirst, watcher, nil).Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var body string
+				err = mysqlRunner.DB().QueryRow("SELECT HEX(body) FROM table_with_charsets WHERE id = 1").Scan(&body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(Equal("F09F9880"))
+			})
+		})
+
+		Context("when NULLs are headed for NOT NULL columns with a default", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_nulls (id integer NOT NULL, name text, score integer, updated_at timestamp)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_nulls (`id` integer NOT NULL, `name` varchar(10) NOT NULL, `score` integer NOT NULL DEFAULT 0, `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_nulls (id, name, score, updated_at) VALUES
+					(1, 'a', 1, '2020-01-01 00:00:00'),
+					(2, 'b', NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_nulls`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_nulls")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("writes the default instead when configured", func() {
+				conversions := &pg2mysql.Conversions{NullDefaults: true}
+				err := pg2mysql.NewMigrator(pg, mysql, conversions, truncateFirst, watcher, nil).Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var score int
+				var recent bool
+				err = mysqlRunner.DB().QueryRow("SELECT score, updated_at > NOW() - INTERVAL 1 HOUR FROM table_with_nulls WHERE id = 2").Scan(&score, &recent)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(score).To(Equal(0))
+				Expect(recent).To(BeTrue())
+			})
+		})
+
+		Context("when dates and timestamps are outside the destination's range", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_dates (id integer NOT NULL, born_on date, created_at timestamp, seen_at timestamp with time zone)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_dates (`id` integer NOT NULL, `born_on` date, `created_at` datetime, `seen_at` timestamp NULL)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_dates (id, born_on, created_at, seen_at) VALUES
+					(1, '0999-12-31', 'infinity', '2038-01-20 00:00:00+00')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_dates`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_dates")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("skips rows out of range by default", func() {
+				err := pg2mysql.NewMigrator(pg, mysql, &pg2mysql.Conversions{}, truncateFirst, watcher, nil).Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var count int
+				err = mysqlRunner.DB().QueryRow("SELECT COUNT(1) FROM table_with_dates").Scan(&count)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(BeZero())
+			})
+
+			It("applies the policy of each column", func() {
+				conversions := &pg2mysql.Conversions{
+					OutOfRange: map[string]map[string]string{
+						"table_with_dates": {"born_on": "clamp", "created_at": "null", "seen_at": "clamp"},
+					},
+				}
+				err := pg2mysql.NewMigrator(pg, mysql, conversions, truncateFirst, watcher, nil).Migrate()
+				Expect(err).NotTo(HaveOccurred())
+
+				var bornOn string
+				var createdAtIsNull bool
+				var seenAt int64
+				stmt := "SELECT CAST(born_on AS CHAR), created_at IS NULL, UNIX_TIMESTAMP(seen_at) FROM table_with_dates WHERE id = 1"
+				err = mysqlRunner.DB().QueryRow(stmt).Scan(&bornOn, &createdAtIsNull, &seenAt)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bornOn).To(Equal("1000-01-01"))
+				Expect(createdAtIsNull).To(BeTrue())
+				Expect(seenAt).To(Equal(int64(2147483647)))
+			})
+
+			It("fails on values out of range by default", func() {
+				err := migrator.Migrate()
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 })
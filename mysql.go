@@ -3,6 +3,8 @@ package pg2mysql
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
@@ -15,7 +17,13 @@ func NewMySQLDB(
 	host string,
 	port int,
 	roundTime bool,
+	location *time.Location,
+	charset string,
 ) DB {
+	if charset == "" {
+		charset = DefaultMySQLCharset
+	}
+
 	config := mysql.NewConfig()
 	config.User = username
 	config.Passwd = password
@@ -24,10 +32,16 @@ func NewMySQLDB(
 	config.Addr = fmt.Sprintf("%s:%d", host, port)
 	config.MultiStatements = true
 	config.Params = map[string]string{
-		"charset":   "utf8",
+		"charset":   charset,
 		"parseTime": "True",
 	}
 
+	// a nil location preserves the session zone of the server
+	if location != nil {
+		config.Loc = location
+		config.Params["time_zone"] = quoteLiteral(mySQLTimeZone(location))
+	}
+
 	return &mySQLDB{
 		dsn:       config.FormatDSN(),
         driver:    "mysql",
@@ -42,6 +56,20 @@ type mySQLDB struct {
 	db        *sql.DB
 	dbName    string
 	roundTime bool
+
+	// mysql8 is set for MySQL 8 servers, whose spatial columns may
+	// restrict their SRID and whose geographic coordinates follow the axis
+	// order of their spatial reference system
+	mysql8 bool
+}
+
+// mySQLTimeZone names location the way MySQL expects it in time_zone. UTC
+// is spelled as an offset so that it works without the time zone tables.
+func mySQLTimeZone(location *time.Location) string {
+	if location == time.UTC {
+		return "+00:00"
+	}
+	return location.String()
 }
 
 func (m *mySQLDB) Open() error {
@@ -52,9 +80,22 @@ func (m *mySQLDB) Open() error {
 
 	m.db = db
 
+	var version string
+	if err := db.QueryRow("SELECT VERSION()").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read server version: %s", err)
+	}
+	m.mysql8 = isMySQL8(version)
+
 	return nil
 }
 
+// isMySQL8 reports whether a server version, such as 8.0.33 or 5.7.44-log,
+// is MySQL 8 or later. MariaDB versions do not count.
+func isMySQL8(version string) bool {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	return err == nil && major >= 8 && !strings.Contains(version, "MariaDB")
+}
+
 func (m *mySQLDB) Close() error {
 	return m.db.Close()
 }
@@ -72,7 +113,16 @@ func (m *mySQLDB) GetSchemaRows() (*sql.Rows, error) {
 	SELECT table_name,
 				 column_name,
 				 data_type,
-				 character_maximum_length
+				 IF(data_type = 'json', @@max_allowed_packet, character_maximum_length),
+				 NULL,
+				 column_type,
+				 numeric_precision,
+				 numeric_scale,
+				 datetime_precision,
+				 character_set_name,
+				 collation_name,
+				 is_nullable,
+				 column_default
 	FROM   information_schema.columns
 	WHERE  table_schema = ?
     ORDER BY table_name, column_name
@@ -85,12 +135,177 @@ func (m *mySQLDB) GetSchemaRows() (*sql.Rows, error) {
 	return rows, nil
 }
 
+// GetEnumLabels returns no labels, as MySQL spells them out in the
+// column_type of each enum column.
+func (m *mySQLDB) GetEnumLabels() (map[string][]string, error) {
+	return map[string][]string{}, nil
+}
+
+// GetSpatialSRIDs returns the SRID each spatial column restricting its
+// values to one takes, by table and column name. Only MySQL 8 columns do.
+func (m *mySQLDB) GetSpatialSRIDs() (map[string]map[string]int64, error) {
+	srids := map[string]map[string]int64{}
+	if !m.mysql8 {
+		return srids, nil
+	}
+
+	stmt := `
+	SELECT table_name,
+	       column_name,
+	       srs_id
+	FROM   information_schema.st_geometry_columns
+	WHERE  table_schema = ?
+	       AND srs_id IS NOT NULL`
+
+	rows, err := m.db.Query(stmt, m.dbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			table  string
+			column string
+			srid   int64
+		)
+		if err := rows.Scan(&table, &column, &srid); err != nil {
+			return nil, err
+		}
+		if srids[table] == nil {
+			srids[table] = map[string]int64{}
+		}
+		srids[table][column] = srid
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate through spatial columns: %s", err)
+	}
+
+	return srids, nil
+}
+
+// GetNoPadCollations returns the collations comparing trailing spaces, the
+// NO PAD collations of MySQL 8. All collations of earlier versions ignore
+// them.
+func (m *mySQLDB) GetNoPadCollations() (map[string]bool, error) {
+	collations := map[string]bool{}
+	if !m.mysql8 {
+		return collations, nil
+	}
+
+	rows, err := m.db.Query("SELECT collation_name FROM information_schema.collations WHERE pad_attribute = 'NO PAD'")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var collation string
+		if err := rows.Scan(&collation); err != nil {
+			return nil, err
+		}
+		collations[collation] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate through collations: %s", err)
+	}
+
+	return collations, nil
+}
+
+// GetUniqueIndexes returns the unique indexes of each table, including the
+// primary key, with their columns in index order.
+func (m *mySQLDB) GetUniqueIndexes() (map[string][]Index, error) {
+	stmt := `
+	SELECT table_name,
+	       index_name,
+	       column_name
+	FROM   information_schema.statistics
+	WHERE  table_schema = ?
+	       AND non_unique = 0
+	ORDER BY table_name, index_name, seq_in_index`
+
+	rows, err := m.db.Query(stmt, m.dbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexes := map[string][]Index{}
+	for rows.Next() {
+		var tableName, indexName, columnName string
+		if err := rows.Scan(&tableName, &indexName, &columnName); err != nil {
+			return nil, err
+		}
+
+		tableIndexes := indexes[tableName]
+		if n := len(tableIndexes); n > 0 && tableIndexes[n-1].Name == indexName {
+			tableIndexes[n-1].Columns = append(tableIndexes[n-1].Columns, columnName)
+		} else {
+			tableIndexes = append(tableIndexes, Index{Name: indexName, Columns: []string{columnName}})
+		}
+		indexes[tableName] = tableIndexes
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return indexes, rows.Close()
+}
+
+// GetPrimaryKeys returns the columns of the primary key of each table, in
+// key order.
+func (m *mySQLDB) GetPrimaryKeys() (map[string][]string, error) {
+	stmt := `
+	SELECT table_name,
+	       column_name
+	FROM   information_schema.statistics
+	WHERE  table_schema = ?
+	       AND index_name = 'PRIMARY'
+	ORDER BY table_name, seq_in_index`
+
+	rows, err := m.db.Query(stmt, m.dbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := map[string][]string{}
+	for rows.Next() {
+		var tableName, columnName string
+		if err := rows.Scan(&tableName, &columnName); err != nil {
+			return nil, err
+		}
+		keys[tableName] = append(keys[tableName], columnName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, rows.Close()
+}
+
 func (m *mySQLDB) DB() *sql.DB {
 	return m.db
 }
 
 func (m *mySQLDB) ColumnNameForSelect(name string) string {
-	return fmt.Sprintf("`%s`", name)
+	return m.QuoteIdentifier(name)
+}
+
+// QuoteIdentifier wraps name in backticks so that reserved words survive,
+// doubling any embedded backtick characters.
+func (m *mySQLDB) QuoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+// QuoteTable qualifies tableName with the database that GetSchemaRows reads from.
+func (m *mySQLDB) QuoteTable(tableName string) string {
+	return m.QuoteIdentifier(m.dbName) + "." + m.QuoteIdentifier(tableName)
 }
 
 func (m *mySQLDB) EnableConstraints() error {
@@ -103,22 +318,117 @@ func (m *mySQLDB) DisableConstraints() error {
 	return err
 }
 
-func (m *mySQLDB) NormalizeTime(t time.Time) time.Time {
+// NormalizeTime rounds or truncates t to the fractional seconds precision
+// of the column, e.g. to microseconds for a datetime(6) column.
+func (m *mySQLDB) NormalizeTime(t time.Time, column *Column) time.Time {
+	unit := FractionalSecondsUnit(column.DatetimePrecision)
 	if m.roundTime {
-		return t.Round(time.Second)
+		return t.Round(unit)
 	}
 
-	return t.Truncate(time.Second)
+	return t.Truncate(unit)
+}
+
+// RoundsTime reports whether times are rounded, rather than truncated, to
+// the fractional seconds precision of their column.
+func (m *mySQLDB) RoundsTime() bool {
+	return m.roundTime
 }
 
 func (m *mySQLDB) ParameterMarker(paramIndex int) string {
 	return "?"
 }
 
-func (m *mySQLDB) ComparisonClause(paramIndex int, columnName string, columnType string) string {
-    clause := fmt.Sprintf("%s <=> %s", m.ColumnNameForSelect(columnName), m.ParameterMarker(paramIndex))
-    if columnType == "uuid" {
-        clause = fmt.Sprintf("%s <=> %s", m.ColumnNameForSelect(columnName), "unhex(replace(" +m.ParameterMarker(paramIndex) + ",'-',''))")
-    }
-    return clause
+func (m *mySQLDB) ParameterForColumn(paramIndex int, src, dst *Column) string {
+	marker := m.ParameterMarker(paramIndex)
+	switch {
+	case src.Type == "uuid":
+		return "unhex(replace(" + marker + ",'-',''))"
+	case dst.Type == "json":
+		return "CAST(" + marker + " AS JSON)"
+	case convertsSpatial(src, dst):
+		return m.geometryFromWKB(marker, src, dst)
+	}
+	return marker
+}
+
+// geometryFromWKB returns SQL reading the geometry bound to marker as
+// spatialExpression spells it for the dst column. Values keeping their own
+// SRID are read once, by a derived table, and split into SRID and WKB.
+func (m *mySQLDB) geometryFromWKB(marker string, src, dst *Column) string {
+	if srid, ok := SpatialSRID(src, dst); ok {
+		return fmt.Sprintf("ST_GeomFromWKB(%s, %d%s)", marker, srid, m.axisOrderOption())
+	}
+	return fmt.Sprintf("(SELECT ST_GeomFromWKB(SUBSTRING(v, 5), CONV(HEX(LEFT(v, 4)), 16, 10)%s) FROM (SELECT %s AS v) AS p)",
+		m.axisOrderOption(), marker)
+}
+
+// axisOrderOption returns the option telling MySQL 8 that WKB holds
+// geographic coordinates in longitude-latitude order, as PostGIS writes
+// them, rather than in the order of their spatial reference system.
+func (m *mySQLDB) axisOrderOption() string {
+	if m.mysql8 {
+		return ", 'axis-order=long-lat'"
+	}
+	return ""
+}
+
+func (m *mySQLDB) ComparisonClause(paramIndex int, src, dst *Column) string {
+	// geometries are compared by their WKB, and their SRID when they keep
+	// their own
+	if convertsSpatial(src, dst) {
+		column := m.ColumnNameForSelect(dst.ActualName)
+		wkb := fmt.Sprintf("ST_AsBinary(%s%s)", column, m.axisOrderOption())
+		if _, ok := SpatialSRID(src, dst); !ok {
+			wkb = fmt.Sprintf("CONCAT(UNHEX(LPAD(HEX(ST_SRID(%s)), 8, '0')), %s)", column, wkb)
+		}
+		return fmt.Sprintf("%s <=> %s", wkb, m.ParameterMarker(paramIndex))
+	}
+	return fmt.Sprintf("%s <=> %s", m.ColumnNameForSelect(dst.ActualName), m.ParameterForColumn(paramIndex, src, dst))
+}
+
+// ChecksumText returns SQL spelling a value of column the way ChecksumText
+// of the database holding other spells the same value, or false when this
+// cannot be done in SQL. Timestamps are spelled in the session time zone,
+// which they were written in.
+func (m *mySQLDB) ChecksumText(expression string, column, other *Column, location *time.Location) (string, bool) {
+	switch column.Type {
+	case "tinyint", "smallint", "mediumint", "int", "bigint", "decimal":
+		return fmt.Sprintf("CAST(%s AS CHAR)", expression), true
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum":
+		return expression, true
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return fmt.Sprintf("LOWER(HEX(%s))", expression), true
+	case "date":
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", expression), true
+	case "datetime", "timestamp":
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d %%H:%%i:%%s.%%f')", expression), true
+	}
+	return "", false
+}
+
+// KeyText returns SQL joining the texts of a key into one, NULLs included,
+// the same on both databases.
+func (m *mySQLDB) KeyText(texts []string) string {
+	values := make([]string, len(texts))
+	for i, text := range texts {
+		values[i] = fmt.Sprintf("COALESCE(%s, CHAR(30 USING utf8mb4))", text)
+	}
+	return fmt.Sprintf("CONCAT_WS(CHAR(31 USING utf8mb4), %s)", strings.Join(values, ", "))
+}
+
+// BinarySortKey returns SQL ordering a text by its UTF-8 bytes rather than
+// by its collation, whatever the character set of its column.
+func (m *mySQLDB) BinarySortKey(expression string) string {
+	return fmt.Sprintf("CAST(CONVERT(%s USING utf8mb4) AS BINARY)", expression)
+}
+
+// RowDigest returns SQL hashing the texts of a row, NULLs included, to a
+// non-negative 60 bit integer, the same on both databases.
+func (m *mySQLDB) RowDigest(texts []string) string {
+	values := make([]string, len(texts))
+	for i, text := range texts {
+		values[i] = fmt.Sprintf("COALESCE(%s, CHAR(30))", text)
+	}
+	return fmt.Sprintf("CAST(CONV(SUBSTRING(MD5(CONCAT_WS(CHAR(31), %s)), 1, 15), 16, 10) AS UNSIGNED)", strings.Join(values, ", "))
 }
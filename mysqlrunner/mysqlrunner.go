@@ -88,10 +88,8 @@ func (runner *Runner) Truncate() error {
 			return err
 		}
 
-		_, err = runner.dbConn.Exec(fmt.Sprintf(`
-			SET FOREIGN_KEY_CHECKS = 0;
-			TRUNCATE TABLE %s.%s;
-			SET FOREIGN_KEY_CHECKS = 1`,
+		_, err = runner.dbConn.Exec(fmt.Sprintf(
+			"SET FOREIGN_KEY_CHECKS = 0; TRUNCATE TABLE `%s`.`%s`; SET FOREIGN_KEY_CHECKS = 1",
 			runner.DBName, tableName))
 		if err != nil {
 			return err
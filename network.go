@@ -0,0 +1,91 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IsNetworkAddressType reports whether a PostgreSQL data type holds IP
+// addresses.
+func IsNetworkAddressType(dataType string) bool {
+	return dataType == "inet" || dataType == "cidr"
+}
+
+// IsBinaryType reports whether a MySQL data type holds byte strings of a
+// declared length.
+func IsBinaryType(dataType string) bool {
+	return dataType == "binary" || dataType == "varbinary"
+}
+
+// ConvertNetworkAddress converts an inet or cidr value for the dst column:
+// binary columns receive the address packed the way MySQL's INET6_ATON packs
+// it, IPv4 addresses mapped to IPv6 in binary(16) columns, and other
+// columns its text. The network prefix is kept in text unless the
+// inet_prefix conversion is "drop"; binary columns cannot hold a prefix, so
+// keeping one that is shorter than the address is an error.
+func (c *Conversions) ConvertNetworkAddress(value string, dst *Column) (interface{}, error) {
+	address, prefix := value, ""
+	if i := strings.IndexByte(value, '/'); i >= 0 {
+		address, prefix = value[:i], value[i+1:]
+	}
+
+	if !IsBinaryType(dst.Type) {
+		if c.InetPrefix == "drop" {
+			return address, nil
+		}
+		return value, nil
+	}
+
+	packed, err := PackNetworkAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix != "" && c.InetPrefix != "drop" && prefix != fmt.Sprint(len(packed)*8) {
+		return nil, fmt.Errorf("network address %s has a prefix a binary column cannot hold", value)
+	}
+
+	// MySQL pads shorter values of binary columns with zeros, which would no
+	// longer read as the address
+	if dst.Type == "binary" && dst.MaxChars == net.IPv6len && len(packed) == net.IPv4len {
+		packed = []byte(net.IP(packed).To16())
+	}
+	if dst.MaxChars > 0 && int64(len(packed)) > dst.MaxChars {
+		return nil, fmt.Errorf("network address %s does not fit in %s(%d)", value, dst.Type, dst.MaxChars)
+	}
+
+	return packed, nil
+}
+
+// PackNetworkAddress returns the 4 byte form of an IPv4 address or the 16
+// byte form of an IPv6 address, as MySQL's INET6_ATON does.
+func PackNetworkAddress(address string) ([]byte, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid network address %q", address)
+	}
+
+	// IPv4-mapped IPv6 addresses keep their 16 bytes
+	if ip4 := ip.To4(); ip4 != nil && !strings.Contains(address, ":") {
+		return []byte(ip4), nil
+	}
+	return []byte(ip.To16()), nil
+}
+
+// ConvertMACAddress converts a macaddr value to 6 bytes for binary columns,
+// and keeps its text otherwise.
+func ConvertMACAddress(value string, dst *Column) (interface{}, error) {
+	if !IsBinaryType(dst.Type) {
+		return value, nil
+	}
+
+	mac, err := net.ParseMAC(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("MAC address %s is not 6 bytes long", value)
+	}
+	return []byte(mac), nil
+}
@@ -0,0 +1,32 @@
+package pg2mysql
+
+import (
+	"fmt"
+)
+
+// ReceivesNulls reports whether NULLs of the src column are headed for a
+// dst column that rejects them.
+func (c *Conversions) ReceivesNulls(src, dst *Column) bool {
+	return src.IsNullable && !dst.IsNullable && !c.writesDefaultForNull(dst)
+}
+
+// writesDefaultForNull reports whether NULLs are written to the dst column
+// as DEFAULT, leaving MySQL to evaluate its default: null_defaults is
+// enabled and the column is NOT NULL with a default.
+func (c *Conversions) writesDefaultForNull(dst *Column) bool {
+	return c.NullDefaults && !dst.IsNullable && dst.Default != nil
+}
+
+// nullChecks flags rows holding NULLs headed for NOT NULL columns of the
+// dst table.
+func nullChecks(db DB, conversions *Conversions, src, dst *Table) rowChecks {
+	var checks rowChecks
+	for _, dstColumn := range dst.Columns {
+		_, srcColumn, err := src.GetColumn(dstColumn)
+		if err != nil || !conversions.ReceivesNulls(srcColumn, dstColumn) {
+			continue
+		}
+		checks.addCondition(db, srcColumn, RuleNullability, fmt.Sprintf("%s IS NULL", columnExpression(db, srcColumn)))
+	}
+	return checks
+}
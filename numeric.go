@@ -0,0 +1,77 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsNumericType reports whether a PostgreSQL data type holds numbers that
+// can be stored in a decimal column.
+func IsNumericType(dataType string) bool {
+	switch dataType {
+	case "smallint", "integer", "bigint", "numeric", "real", "double precision":
+		return true
+	}
+	return false
+}
+
+func IsDecimalType(dataType string) bool {
+	return dataType == "decimal" || dataType == "numeric"
+}
+
+// integerDigits returns how many digits a column can hold left of the
+// decimal point, or -1 when it is unbounded or not known exactly.
+func integerDigits(c *Column) int64 {
+	switch c.Type {
+	case "smallint":
+		return 5
+	case "integer":
+		return 10
+	case "bigint":
+		return 19
+	case "numeric", "decimal":
+		if c.NumericPrecision > 0 {
+			return c.NumericPrecision - c.NumericScale
+		}
+	}
+	return -1
+}
+
+// NumericFits reports whether every value the src column can hold fits the
+// dst decimal column without overflowing or losing fractional digits.
+func NumericFits(src, dst *Column) bool {
+	srcDigits, dstDigits := integerDigits(src), integerDigits(dst)
+	if dstDigits < 0 {
+		return true
+	}
+	if srcDigits < 0 || srcDigits > dstDigits {
+		return false
+	}
+
+	return src.NumericScale <= dst.NumericScale
+}
+
+// numericConditions returns SQL predicates selecting rows whose values
+// would overflow the integer digits of the dst decimal column, and rows
+// that would lose fractional digits beyond its scale. Either is empty when
+// no value of the src column can fail it.
+func numericConditions(db DB, src, dst *Column) (overflow, loss string) {
+	column := columnExpression(db, src)
+	value := fmt.Sprintf("CAST(%s AS NUMERIC)", column)
+	if src.Type == "real" || src.Type == "double precision" {
+		// infinities cannot be cast to numeric, treat them like NaN which
+		// no decimal column can hold either
+		value = fmt.Sprintf("(CASE WHEN %s IN ('Infinity', '-Infinity') THEN CAST('NaN' AS NUMERIC) ELSE %s END)", column, value)
+	}
+
+	if digits := integerDigits(dst); digits >= 0 {
+		limit := "1" + strings.Repeat("0", int(digits))
+		overflow = fmt.Sprintf("ROUND(ABS(%s), %d) >= %s", value, dst.NumericScale, limit)
+	}
+
+	if src.Type != "smallint" && src.Type != "integer" && src.Type != "bigint" {
+		loss = fmt.Sprintf("%s <> ROUND(%s, %d)", value, value, dst.NumericScale)
+	}
+
+	return overflow, loss
+}
@@ -0,0 +1,158 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Out of range policies for dates and timestamps a destination column
+// cannot hold.
+const (
+	OutOfRangeFail  = "fail"
+	OutOfRangeClamp = "clamp"
+	OutOfRangeNull  = "null"
+)
+
+// OutOfRangeError is the error converting a date or timestamp outside the
+// range of its destination column, under the fail policy. The migrator
+// skips rows holding one, and the verifier compares them unconverted.
+type OutOfRangeError struct {
+	TableName  string
+	ColumnName string
+	Value      string
+	Type       string
+}
+
+func (e *OutOfRangeError) Error() string {
+	return fmt.Sprintf("%s.%s: %s is outside the range of %s", e.TableName, e.ColumnName, e.Value, e.Type)
+}
+
+// IsDateType reports whether a PostgreSQL data type holds dates, with or
+// without a time of day.
+func IsDateType(dataType string) bool {
+	switch dataType {
+	case "date", "timestamp with time zone", "timestamp without time zone":
+		return true
+	}
+	return false
+}
+
+// DateRange returns the earliest and latest value a MySQL DATE, DATETIME or
+// TIMESTAMP column can hold, at its fractional seconds precision. ok is
+// false for other types.
+func DateRange(dst *Column) (min, max time.Time, ok bool) {
+	last := time.Second - FractionalSecondsUnit(dst.DatetimePrecision)
+
+	switch dst.Type {
+	case "date":
+		return time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC), true
+	case "datetime":
+		return time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(9999, 12, 31, 23, 59, 59, int(last), time.UTC), true
+	case "timestamp":
+		return time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC), time.Date(2038, 1, 19, 3, 14, 7, int(last), time.UTC), true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// sourceDateRange returns the range of the dst column for values of the src
+// column. Timestamps headed for DATE columns lose their time of day, so
+// the whole of the last day fits.
+func sourceDateRange(src, dst *Column) (min, max time.Time, ok bool) {
+	min, max, ok = DateRange(dst)
+	if ok && src.Type != "date" && dst.Type == "date" {
+		max = max.Add(24*time.Hour - time.Microsecond)
+	}
+	return min, max, ok
+}
+
+// OutOfRangePolicy returns the policy configured for a source column.
+func (c *Conversions) OutOfRangePolicy(tableName, columnName string) string {
+	if policy := c.OutOfRange[tableName][columnName]; policy != "" {
+		return strings.ToLower(policy)
+	}
+	return OutOfRangeFail
+}
+
+// applyOutOfRangePolicy checks a date or timestamp read from the src column
+// against the range of the dst column, including PostgreSQL's infinity and
+// -infinity, and applies the policy configured for the column to values
+// outside it. Timestamps without a time zone are compared by wall clock.
+func (c *Conversions) applyOutOfRangePolicy(tableName string, src, dst *Column, value interface{}) (interface{}, error) {
+	min, max, ok := sourceDateRange(src, dst)
+	if !ok || !IsDateType(src.Type) || value == nil {
+		return value, nil
+	}
+
+	var clamped time.Time
+	switch v := value.(type) {
+	case time.Time:
+		wall := v
+		if src.Type != "timestamp with time zone" {
+			wall = time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), time.UTC)
+		}
+		switch {
+		case wall.Before(min):
+			clamped = min
+		case wall.After(max):
+			clamped = max
+		default:
+			return value, nil
+		}
+	case []byte:
+		switch string(v) {
+		case "-infinity":
+			clamped = min
+		case "infinity":
+			clamped = max
+		default:
+			return value, nil
+		}
+	default:
+		return value, nil
+	}
+
+	switch c.OutOfRangePolicy(tableName, src.ActualName) {
+	case OutOfRangeClamp:
+		return clamped, nil
+	case OutOfRangeNull:
+		return nil, nil
+	}
+	return nil, &OutOfRangeError{TableName: tableName, ColumnName: src.ActualName, Value: formatValue(value), Type: dst.Type}
+}
+
+func formatValue(value interface{}) string {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(value)
+}
+
+// outOfRangeChecks flags rows holding dates or timestamps outside the range
+// of their dst column, for the columns whose policy is to fail.
+func outOfRangeChecks(db DB, conversions *Conversions, src, dst *Table) rowChecks {
+	var checks rowChecks
+	for _, dstColumn := range dst.Columns {
+		_, srcColumn, err := src.GetColumn(dstColumn)
+		if err != nil || !IsDateType(srcColumn.Type) {
+			continue
+		}
+
+		min, max, ok := sourceDateRange(srcColumn, dstColumn)
+		if !ok || conversions.OutOfRangePolicy(src.ActualName, srcColumn.ActualName) != OutOfRangeFail {
+			continue
+		}
+
+		column, layout := columnExpression(db, srcColumn), "2006-01-02 15:04:05.999999"
+		switch {
+		case srcColumn.Type == "timestamp with time zone":
+			layout += "-07"
+		case srcColumn.Type == "date" && dstColumn.Type != "date":
+			column = fmt.Sprintf("CAST(%s AS timestamp without time zone)", column)
+		}
+
+		checks.addCondition(db, srcColumn, RuleRange, fmt.Sprintf("(%s < %s OR %s > %s)",
+			column, quoteLiteral(min.Format(layout)), column, quoteLiteral(max.Format(layout))))
+	}
+	return checks
+}
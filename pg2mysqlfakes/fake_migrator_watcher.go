@@ -46,6 +46,12 @@ type FakeMigratorWatcher struct {
 	tableMigrationDidStartArgsForCall []struct {
 		tableName string
 	}
+	TableMigrationInProgressStub        func(tableName string, recordsInserted int64)
+	tableMigrationInProgressMutex       sync.RWMutex
+	tableMigrationInProgressArgsForCall []struct {
+		tableName       string
+		recordsInserted int64
+	}
 	TableMigrationDidFinishStub        func(tableName string, recordsInserted int64)
 	tableMigrationDidFinishMutex       sync.RWMutex
 	tableMigrationDidFinishArgsForCall []struct {
@@ -259,6 +265,31 @@ func (fake *FakeMigratorWatcher) TableMigrationDidStartArgsForCall(i int) string
 	return fake.tableMigrationDidStartArgsForCall[i].tableName
 }
 
+func (fake *FakeMigratorWatcher) TableMigrationInProgress(tableName string, recordsInserted int64) {
+	fake.tableMigrationInProgressMutex.Lock()
+	fake.tableMigrationInProgressArgsForCall = append(fake.tableMigrationInProgressArgsForCall, struct {
+		tableName       string
+		recordsInserted int64
+	}{tableName, recordsInserted})
+	fake.recordInvocation("TableMigrationInProgress", []interface{}{tableName, recordsInserted})
+	fake.tableMigrationInProgressMutex.Unlock()
+	if fake.TableMigrationInProgressStub != nil {
+		fake.TableMigrationInProgressStub(tableName, recordsInserted)
+	}
+}
+
+func (fake *FakeMigratorWatcher) TableMigrationInProgressCallCount() int {
+	fake.tableMigrationInProgressMutex.RLock()
+	defer fake.tableMigrationInProgressMutex.RUnlock()
+	return len(fake.tableMigrationInProgressArgsForCall)
+}
+
+func (fake *FakeMigratorWatcher) TableMigrationInProgressArgsForCall(i int) (string, int64) {
+	fake.tableMigrationInProgressMutex.RLock()
+	defer fake.tableMigrationInProgressMutex.RUnlock()
+	return fake.tableMigrationInProgressArgsForCall[i].tableName, fake.tableMigrationInProgressArgsForCall[i].recordsInserted
+}
+
 func (fake *FakeMigratorWatcher) TableMigrationDidFinish(tableName string, recordsInserted int64) {
 	fake.tableMigrationDidFinishMutex.Lock()
 	fake.tableMigrationDidFinishArgsForCall = append(fake.tableMigrationDidFinishArgsForCall, struct {
@@ -356,6 +387,8 @@ func (fake *FakeMigratorWatcher) Invocations() map[string][][]interface{} {
 	defer fake.truncateTableDidFinishMutex.RUnlock()
 	fake.tableMigrationDidStartMutex.RLock()
 	defer fake.tableMigrationDidStartMutex.RUnlock()
+	fake.tableMigrationInProgressMutex.RLock()
+	defer fake.tableMigrationInProgressMutex.RUnlock()
 	fake.tableMigrationDidFinishMutex.RLock()
 	defer fake.tableMigrationDidFinishMutex.RUnlock()
 	fake.didMigrateRowMutex.RLock()
@@ -13,12 +13,14 @@ type FakeVerifierWatcher struct {
 	tableVerificationDidStartArgsForCall []struct {
 		tableName string
 	}
-	TableVerificationDidFinishStub        func(tableName string, missingRows int64, missingIDs []string)
+	TableVerificationDidFinishStub        func(tableName string, missingRows int64, missingIDs []string, extraRows int64, extraIDs []string)
 	tableVerificationDidFinishMutex       sync.RWMutex
 	tableVerificationDidFinishArgsForCall []struct {
 		tableName   string
 		missingRows int64
 		missingIDs  []string
+		extraRows   int64
+		extraIDs    []string
 	}
 	TableVerificationDidFinishWithErrorStub        func(tableName string, err error)
 	tableVerificationDidFinishWithErrorMutex       sync.RWMutex
@@ -26,6 +28,26 @@ type FakeVerifierWatcher struct {
 		tableName string
 		err       error
 	}
+	TableVerificationDidFindSchemaProblemsStub        func(tableName string, problems []string)
+	tableVerificationDidFindSchemaProblemsMutex       sync.RWMutex
+	tableVerificationDidFindSchemaProblemsArgsForCall []struct {
+		tableName string
+		problems  []string
+	}
+	TableVerificationDidFindDifferencesStub        func(tableName string, missingIDs, extraIDs, changedIDs []string)
+	tableVerificationDidFindDifferencesMutex       sync.RWMutex
+	tableVerificationDidFindDifferencesArgsForCall []struct {
+		tableName  string
+		missingIDs []string
+		extraIDs   []string
+		changedIDs []string
+	}
+	TableVerificationDidFindColumnDiffsStub        func(tableName string, diffs []pg2mysql.ColumnDiff)
+	tableVerificationDidFindColumnDiffsMutex       sync.RWMutex
+	tableVerificationDidFindColumnDiffsArgsForCall []struct {
+		tableName string
+		diffs     []pg2mysql.ColumnDiff
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -54,22 +76,29 @@ func (fake *FakeVerifierWatcher) TableVerificationDidStartArgsForCall(i int) str
 	return fake.tableVerificationDidStartArgsForCall[i].tableName
 }
 
-func (fake *FakeVerifierWatcher) TableVerificationDidFinish(tableName string, missingRows int64, missingIDs []string) {
+func (fake *FakeVerifierWatcher) TableVerificationDidFinish(tableName string, missingRows int64, missingIDs []string, extraRows int64, extraIDs []string) {
 	var missingIDsCopy []string
 	if missingIDs != nil {
 		missingIDsCopy = make([]string, len(missingIDs))
 		copy(missingIDsCopy, missingIDs)
 	}
+	var extraIDsCopy []string
+	if extraIDs != nil {
+		extraIDsCopy = make([]string, len(extraIDs))
+		copy(extraIDsCopy, extraIDs)
+	}
 	fake.tableVerificationDidFinishMutex.Lock()
 	fake.tableVerificationDidFinishArgsForCall = append(fake.tableVerificationDidFinishArgsForCall, struct {
 		tableName   string
 		missingRows int64
 		missingIDs  []string
-	}{tableName, missingRows, missingIDsCopy})
-	fake.recordInvocation("TableVerificationDidFinish", []interface{}{tableName, missingRows, missingIDsCopy})
+		extraRows   int64
+		extraIDs    []string
+	}{tableName, missingRows, missingIDsCopy, extraRows, extraIDsCopy})
+	fake.recordInvocation("TableVerificationDidFinish", []interface{}{tableName, missingRows, missingIDsCopy, extraRows, extraIDsCopy})
 	fake.tableVerificationDidFinishMutex.Unlock()
 	if fake.TableVerificationDidFinishStub != nil {
-		fake.TableVerificationDidFinishStub(tableName, missingRows, missingIDs)
+		fake.TableVerificationDidFinishStub(tableName, missingRows, missingIDs, extraRows, extraIDs)
 	}
 }
 
@@ -79,10 +108,10 @@ func (fake *FakeVerifierWatcher) TableVerificationDidFinishCallCount() int {
 	return len(fake.tableVerificationDidFinishArgsForCall)
 }
 
-func (fake *FakeVerifierWatcher) TableVerificationDidFinishArgsForCall(i int) (string, int64, []string) {
+func (fake *FakeVerifierWatcher) TableVerificationDidFinishArgsForCall(i int) (string, int64, []string, int64, []string) {
 	fake.tableVerificationDidFinishMutex.RLock()
 	defer fake.tableVerificationDidFinishMutex.RUnlock()
-	return fake.tableVerificationDidFinishArgsForCall[i].tableName, fake.tableVerificationDidFinishArgsForCall[i].missingRows, fake.tableVerificationDidFinishArgsForCall[i].missingIDs
+	return fake.tableVerificationDidFinishArgsForCall[i].tableName, fake.tableVerificationDidFinishArgsForCall[i].missingRows, fake.tableVerificationDidFinishArgsForCall[i].missingIDs, fake.tableVerificationDidFinishArgsForCall[i].extraRows, fake.tableVerificationDidFinishArgsForCall[i].extraIDs
 }
 
 func (fake *FakeVerifierWatcher) TableVerificationDidFinishWithError(tableName string, err error) {
@@ -110,6 +139,108 @@ func (fake *FakeVerifierWatcher) TableVerificationDidFinishWithErrorArgsForCall(
 	return fake.tableVerificationDidFinishWithErrorArgsForCall[i].tableName, fake.tableVerificationDidFinishWithErrorArgsForCall[i].err
 }
 
+func (fake *FakeVerifierWatcher) TableVerificationDidFindSchemaProblems(tableName string, problems []string) {
+	var problemsCopy []string
+	if problems != nil {
+		problemsCopy = make([]string, len(problems))
+		copy(problemsCopy, problems)
+	}
+	fake.tableVerificationDidFindSchemaProblemsMutex.Lock()
+	fake.tableVerificationDidFindSchemaProblemsArgsForCall = append(fake.tableVerificationDidFindSchemaProblemsArgsForCall, struct {
+		tableName string
+		problems  []string
+	}{tableName, problemsCopy})
+	fake.recordInvocation("TableVerificationDidFindSchemaProblems", []interface{}{tableName, problemsCopy})
+	fake.tableVerificationDidFindSchemaProblemsMutex.Unlock()
+	if fake.TableVerificationDidFindSchemaProblemsStub != nil {
+		fake.TableVerificationDidFindSchemaProblemsStub(tableName, problems)
+	}
+}
+
+func (fake *FakeVerifierWatcher) TableVerificationDidFindSchemaProblemsCallCount() int {
+	fake.tableVerificationDidFindSchemaProblemsMutex.RLock()
+	defer fake.tableVerificationDidFindSchemaProblemsMutex.RUnlock()
+	return len(fake.tableVerificationDidFindSchemaProblemsArgsForCall)
+}
+
+func (fake *FakeVerifierWatcher) TableVerificationDidFindSchemaProblemsArgsForCall(i int) (string, []string) {
+	fake.tableVerificationDidFindSchemaProblemsMutex.RLock()
+	defer fake.tableVerificationDidFindSchemaProblemsMutex.RUnlock()
+	return fake.tableVerificationDidFindSchemaProblemsArgsForCall[i].tableName, fake.tableVerificationDidFindSchemaProblemsArgsForCall[i].problems
+}
+
+func (fake *FakeVerifierWatcher) TableVerificationDidFindDifferences(tableName string, missingIDs []string, extraIDs []string, changedIDs []string) {
+	var missingIDsCopy []string
+	if missingIDs != nil {
+		missingIDsCopy = make([]string, len(missingIDs))
+		copy(missingIDsCopy, missingIDs)
+	}
+	var extraIDsCopy []string
+	if extraIDs != nil {
+		extraIDsCopy = make([]string, len(extraIDs))
+		copy(extraIDsCopy, extraIDs)
+	}
+	var changedIDsCopy []string
+	if changedIDs != nil {
+		changedIDsCopy = make([]string, len(changedIDs))
+		copy(changedIDsCopy, changedIDs)
+	}
+	fake.tableVerificationDidFindDifferencesMutex.Lock()
+	fake.tableVerificationDidFindDifferencesArgsForCall = append(fake.tableVerificationDidFindDifferencesArgsForCall, struct {
+		tableName  string
+		missingIDs []string
+		extraIDs   []string
+		changedIDs []string
+	}{tableName, missingIDsCopy, extraIDsCopy, changedIDsCopy})
+	fake.recordInvocation("TableVerificationDidFindDifferences", []interface{}{tableName, missingIDsCopy, extraIDsCopy, changedIDsCopy})
+	fake.tableVerificationDidFindDifferencesMutex.Unlock()
+	if fake.TableVerificationDidFindDifferencesStub != nil {
+		fake.TableVerificationDidFindDifferencesStub(tableName, missingIDs, extraIDs, changedIDs)
+	}
+}
+
+func (fake *FakeVerifierWatcher) TableVerificationDidFindDifferencesCallCount() int {
+	fake.tableVerificationDidFindDifferencesMutex.RLock()
+	defer fake.tableVerificationDidFindDifferencesMutex.RUnlock()
+	return len(fake.tableVerificationDidFindDifferencesArgsForCall)
+}
+
+func (fake *FakeVerifierWatcher) TableVerificationDidFindDifferencesArgsForCall(i int) (string, []string, []string, []string) {
+	fake.tableVerificationDidFindDifferencesMutex.RLock()
+	defer fake.tableVerificationDidFindDifferencesMutex.RUnlock()
+	return fake.tableVerificationDidFindDifferencesArgsForCall[i].tableName, fake.tableVerificationDidFindDifferencesArgsForCall[i].missingIDs, fake.tableVerificationDidFindDifferencesArgsForCall[i].extraIDs, fake.tableVerificationDidFindDifferencesArgsForCall[i].changedIDs
+}
+
+func (fake *FakeVerifierWatcher) TableVerificationDidFindColumnDiffs(tableName string, diffs []pg2mysql.ColumnDiff) {
+	var diffsCopy []pg2mysql.ColumnDiff
+	if diffs != nil {
+		diffsCopy = make([]pg2mysql.ColumnDiff, len(diffs))
+		copy(diffsCopy, diffs)
+	}
+	fake.tableVerificationDidFindColumnDiffsMutex.Lock()
+	fake.tableVerificationDidFindColumnDiffsArgsForCall = append(fake.tableVerificationDidFindColumnDiffsArgsForCall, struct {
+		tableName string
+		diffs     []pg2mysql.ColumnDiff
+	}{tableName, diffsCopy})
+	fake.recordInvocation("TableVerificationDidFindColumnDiffs", []interface{}{tableName, diffsCopy})
+	fake.tableVerificationDidFindColumnDiffsMutex.Unlock()
+	if fake.TableVerificationDidFindColumnDiffsStub != nil {
+		fake.TableVerificationDidFindColumnDiffsStub(tableName, diffs)
+	}
+}
+
+func (fake *FakeVerifierWatcher) TableVerificationDidFindColumnDiffsCallCount() int {
+	fake.tableVerificationDidFindColumnDiffsMutex.RLock()
+	defer fake.tableVerificationDidFindColumnDiffsMutex.RUnlock()
+	return len(fake.tableVerificationDidFindColumnDiffsArgsForCall)
+}
+
+func (fake *FakeVerifierWatcher) TableVerificationDidFindColumnDiffsArgsForCall(i int) (string, []pg2mysql.ColumnDiff) {
+	fake.tableVerificationDidFindColumnDiffsMutex.RLock()
+	defer fake.tableVerificationDidFindColumnDiffsMutex.RUnlock()
+	return fake.tableVerificationDidFindColumnDiffsArgsForCall[i].tableName, fake.tableVerificationDidFindColumnDiffsArgsForCall[i].diffs
+}
+
 func (fake *FakeVerifierWatcher) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -119,6 +250,12 @@ func (fake *FakeVerifierWatcher) Invocations() map[string][][]interface{} {
 	defer fake.tableVerificationDidFinishMutex.RUnlock()
 	fake.tableVerificationDidFinishWithErrorMutex.RLock()
 	defer fake.tableVerificationDidFinishWithErrorMutex.RUnlock()
+	fake.tableVerificationDidFindSchemaProblemsMutex.RLock()
+	defer fake.tableVerificationDidFindSchemaProblemsMutex.RUnlock()
+	fake.tableVerificationDidFindDifferencesMutex.RLock()
+	defer fake.tableVerificationDidFindDifferencesMutex.RUnlock()
+	fake.tableVerificationDidFindColumnDiffsMutex.RLock()
+	defer fake.tableVerificationDidFindColumnDiffsMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -3,6 +3,7 @@ package pg2mysql
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	_ "github.com/lib/pq" // register postgres driver
@@ -67,13 +68,27 @@ func (p *postgreSQLDB) GetSchemaRows() (*sql.Rows, error) {
 	SELECT t1.table_name,
 	       t1.column_name,
 	       t1.data_type,
-	       t1.character_maximum_length
+	       t1.character_maximum_length,
+	       t1.udt_name,
+	       CASE WHEN t1.data_type = 'USER-DEFINED' THEN
+	         (SELECT format_type(a.atttypid, a.atttypmod)
+	          FROM   pg_attribute a
+	          WHERE  a.attrelid = format('%I.%I', t1.table_schema, t1.table_name)::regclass
+	                 AND a.attname = t1.column_name)
+	       END,
+	       CASE WHEN t1.numeric_precision_radix = 10 THEN t1.numeric_precision END,
+	       CASE WHEN t1.numeric_precision_radix = 10 THEN t1.numeric_scale END,
+	       t1.datetime_precision,
+	       NULL,
+	       NULL,
+	       t1.is_nullable,
+	       t1.column_default
 	FROM   information_schema.columns t1
 	       JOIN information_schema.tables t2
 	         ON t2.table_name = t1.table_name
 	            AND t2.table_type = 'BASE TABLE'
 	WHERE  t1.table_schema = 'public'
-	       AND t1.table_name NOT IN ('schema_migrations')
+	       AND t1.table_name NOT IN ('schema_migrations', 'spatial_ref_sys')
 	       AND t1.table_catalog = $1
     ORDER BY 1, 2`
 
@@ -85,12 +100,119 @@ func (p *postgreSQLDB) GetSchemaRows() (*sql.Rows, error) {
 	return rows, nil
 }
 
+// GetSpatialSRIDs returns no SRIDs, as PostGIS columns declare theirs in
+// their column type.
+func (p *postgreSQLDB) GetSpatialSRIDs() (map[string]map[string]int64, error) {
+	return map[string]map[string]int64{}, nil
+}
+
+// GetNoPadCollations returns no collations, as source strings are not
+// compared under the collations of their columns.
+func (p *postgreSQLDB) GetNoPadCollations() (map[string]bool, error) {
+	return map[string]bool{}, nil
+}
+
+// GetUniqueIndexes returns no indexes, as collisions are only checked
+// against the unique indexes of the destination.
+func (p *postgreSQLDB) GetUniqueIndexes() (map[string][]Index, error) {
+	return map[string][]Index{}, nil
+}
+
+// GetPrimaryKeys returns the columns of the primary key of each table of
+// the schema that GetSchemaRows reads from, in key order.
+func (p *postgreSQLDB) GetPrimaryKeys() (map[string][]string, error) {
+	stmt := `
+	SELECT c.relname,
+	       a.attname
+	FROM   pg_index i
+	       JOIN pg_class c
+	         ON c.oid = i.indrelid
+	       JOIN pg_namespace n
+	         ON n.oid = c.relnamespace
+	       JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, position)
+	         ON true
+	       JOIN pg_attribute a
+	         ON a.attrelid = c.oid
+	            AND a.attnum = k.attnum
+	WHERE  i.indisprimary
+	       AND n.nspname = 'public'
+	ORDER BY c.relname, k.position`
+
+	rows, err := p.db.Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := map[string][]string{}
+	for rows.Next() {
+		var tableName, columnName string
+		if err := rows.Scan(&tableName, &columnName); err != nil {
+			return nil, err
+		}
+		keys[tableName] = append(keys[tableName], columnName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, rows.Close()
+}
+
+// GetEnumLabels returns the labels of the enum types of the schema that
+// GetSchemaRows reads from, in their sort order.
+func (p *postgreSQLDB) GetEnumLabels() (map[string][]string, error) {
+	stmt := `
+	SELECT t.typname,
+	       e.enumlabel
+	FROM   pg_type t
+	       JOIN pg_enum e
+	         ON e.enumtypid = t.oid
+	       JOIN pg_namespace n
+	         ON n.oid = t.typnamespace
+	WHERE  n.nspname = 'public'
+	ORDER BY t.typname, e.enumsortorder`
+
+	rows, err := p.db.Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	labels := map[string][]string{}
+	for rows.Next() {
+		var typeName, label string
+		if err := rows.Scan(&typeName, &label); err != nil {
+			return nil, err
+		}
+		labels[typeName] = append(labels[typeName], label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return labels, rows.Close()
+}
+
 func (p *postgreSQLDB) DB() *sql.DB {
 	return p.db
 }
 
 func (p *postgreSQLDB) ColumnNameForSelect(name string) string {
-	return name
+	return p.QuoteIdentifier(name)
+}
+
+// QuoteIdentifier wraps name in double quotes so that reserved words and
+// mixed case names survive, doubling any embedded quote characters.
+func (p *postgreSQLDB) QuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// QuoteTable qualifies tableName with the schema that GetSchemaRows reads from.
+func (p *postgreSQLDB) QuoteTable(tableName string) string {
+	return p.QuoteIdentifier("public") + "." + p.QuoteIdentifier(tableName)
 }
 
 func (p *postgreSQLDB) EnableConstraints() error {
@@ -103,15 +225,86 @@ func (p *postgreSQLDB) DisableConstraints() error {
 	return nil
 }
 
-func (p *postgreSQLDB) NormalizeTime(t time.Time) time.Time {
+func (p *postgreSQLDB) NormalizeTime(t time.Time, column *Column) time.Time {
 	return t
 }
 
+// RoundsTime reports true, as postgres rounds times to the fractional
+// seconds precision of their column itself.
+func (p *postgreSQLDB) RoundsTime() bool {
+	return true
+}
+
 func (p *postgreSQLDB) ParameterMarker(paramIndex int) string {
 	// postgres parameters are 1 indexed, go arrays are 0 indexed
 	return fmt.Sprintf("$%d", paramIndex+1)
 }
 
-func (p *postgreSQLDB) ComparisonClause(paramIndex int, columnName string, columnType string) string {
-	return fmt.Sprintf("NOT(%s IS DISTINCT FROM %s)", p.ColumnNameForSelect(columnName), p.ParameterMarker(paramIndex))
+func (p *postgreSQLDB) ParameterForColumn(paramIndex int, src, dst *Column) string {
+	return p.ParameterMarker(paramIndex)
+}
+
+func (p *postgreSQLDB) ComparisonClause(paramIndex int, src, dst *Column) string {
+	return fmt.Sprintf("NOT(%s IS DISTINCT FROM %s)", p.ColumnNameForSelect(dst.ActualName), p.ParameterForColumn(paramIndex, src, dst))
+}
+
+// ChecksumText returns SQL spelling a value of column the way ChecksumText
+// of the database holding other spells the same value, or false when this
+// cannot be done in SQL. Timestamps with time zone are spelled in location,
+// or in UTC, the zone the MySQL driver writes them in by default. Times
+// with more fractional seconds than other holds are spelled as given, so
+// expression has to drop them first.
+func (p *postgreSQLDB) ChecksumText(expression string, column, other *Column, location *time.Location) (string, bool) {
+	switch {
+	case column.Type == "boolean":
+		return fmt.Sprintf("CASE WHEN %s THEN '1' WHEN NOT %s THEN '0' END", expression, expression), true
+	case column.Type == "smallint", column.Type == "integer", column.Type == "bigint",
+		IsPostgreSQLTextType(column.Type), column.Type == "USER-DEFINED" && (column.UDTName == "citext" || IsEnumType(column)):
+		return fmt.Sprintf("CAST(%s AS TEXT)", expression), true
+	case column.Type == "numeric" && IsDecimalType(other.Type) && other.NumericPrecision > 0:
+		return fmt.Sprintf("CAST(ROUND(%s, %d) AS TEXT)", expression, other.NumericScale), true
+	case column.Type == "uuid" && IsBinaryType(other.Type):
+		return fmt.Sprintf("REPLACE(CAST(%s AS TEXT), '-', '')", expression), true
+	case column.Type == "uuid":
+		return fmt.Sprintf("CAST(%s AS TEXT)", expression), true
+	case column.Type == "bytea":
+		return fmt.Sprintf("encode(%s, 'hex')", expression), true
+	case column.Type == "date":
+		return fmt.Sprintf("to_char(%s, 'YYYY-MM-DD')", expression), true
+	case column.Type == "timestamp without time zone":
+		return fmt.Sprintf("to_char(%s, 'YYYY-MM-DD HH24:MI:SS.US')", expression), true
+	case column.Type == "timestamp with time zone":
+		zone := "UTC"
+		if location != nil {
+			zone = location.String()
+		}
+		return fmt.Sprintf("to_char(%s AT TIME ZONE %s, 'YYYY-MM-DD HH24:MI:SS.US')", expression, quoteLiteral(zone)), true
+	}
+	return "", false
+}
+
+// KeyText returns SQL joining the texts of a key into one, NULLs included,
+// the same on both databases.
+func (p *postgreSQLDB) KeyText(texts []string) string {
+	values := make([]string, len(texts))
+	for i, text := range texts {
+		values[i] = fmt.Sprintf("COALESCE(%s, chr(30))", text)
+	}
+	return fmt.Sprintf("concat_ws(chr(31), %s)", strings.Join(values, ", "))
+}
+
+// BinarySortKey returns SQL ordering a text by its UTF-8 bytes rather than
+// by the collation of the database.
+func (p *postgreSQLDB) BinarySortKey(expression string) string {
+	return fmt.Sprintf("convert_to(%s, 'UTF8')", expression)
+}
+
+// RowDigest returns SQL hashing the texts of a row, NULLs included, to a
+// non-negative 60 bit integer, the same on both databases.
+func (p *postgreSQLDB) RowDigest(texts []string) string {
+	values := make([]string, len(texts))
+	for i, text := range texts {
+		values[i] = fmt.Sprintf("COALESCE(%s, chr(30))", text)
+	}
+	return fmt.Sprintf("CAST(CAST('x' || substr(md5(concat_ws(chr(31), %s)), 1, 15) AS bit(60)) AS bigint)", strings.Join(values, ", "))
 }
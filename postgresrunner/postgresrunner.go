@@ -79,7 +79,7 @@ func (runner *Runner) Truncate() error {
 			return err
 		}
 
-		_, err = runner.dbConn.Exec(fmt.Sprintf(`TRUNCATE TABLE %s`, tableName))
+		_, err = runner.dbConn.Exec(fmt.Sprintf(`TRUNCATE TABLE "%s"`, tableName))
 		if err != nil {
 			return err
 		}
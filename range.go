@@ -0,0 +1,192 @@
+package pg2mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// rangeSubtypes maps the built-in PostgreSQL range types to the data type
+// of their bounds.
+var rangeSubtypes = map[string]string{
+	"int4range": "integer",
+	"int8range": "bigint",
+	"numrange":  "numeric",
+	"tsrange":   "timestamp without time zone",
+	"tstzrange": "timestamp with time zone",
+	"daterange": "date",
+}
+
+// IsRangeType reports whether a PostgreSQL data type is a built-in range
+// type.
+func IsRangeType(dataType string) bool {
+	_, ok := rangeSubtypes[dataType]
+	return ok
+}
+
+// Range is a parsed PostgreSQL range literal. Lower and Upper are nil for
+// unbounded ends, and Bounds spells the inclusivity of both ends, e.g. [).
+type Range struct {
+	Lower, Upper *string
+	Bounds       string
+	Empty        bool
+}
+
+// ParseRange parses a PostgreSQL range literal such as [1,5), ("a b",] or
+// empty.
+func ParseRange(literal string) (Range, error) {
+	if literal == "empty" {
+		return Range{Empty: true, Bounds: "empty"}, nil
+	}
+
+	if len(literal) < 3 || !strings.ContainsRune("[(", rune(literal[0])) || !strings.ContainsRune("])", rune(literal[len(literal)-1])) {
+		return Range{}, fmt.Errorf("malformed range literal %q", literal)
+	}
+
+	var bounds []*string
+	var bound strings.Builder
+	var quoted, escaped, present bool
+	for _, c := range literal[1 : len(literal)-1] {
+		switch {
+		case escaped:
+			bound.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+			present = true
+		case c == ',' && !quoted:
+			bounds = append(bounds, rangeBound(bound.String(), present))
+			bound.Reset()
+			present = false
+		default:
+			bound.WriteRune(c)
+			present = true
+		}
+	}
+	bounds = append(bounds, rangeBound(bound.String(), present))
+
+	if len(bounds) != 2 || quoted || escaped {
+		return Range{}, fmt.Errorf("malformed range literal %q", literal)
+	}
+
+	return Range{
+		Lower:  bounds[0],
+		Upper:  bounds[1],
+		Bounds: string(literal[0]) + string(literal[len(literal)-1]),
+	}, nil
+}
+
+func rangeBound(value string, present bool) *string {
+	if !present {
+		return nil
+	}
+	return &value
+}
+
+// RangeToJSON encodes a range of the given range type as a JSON document
+// {"lower": ..., "upper": ..., "bounds": "[)"}. Bounds of numeric ranges
+// are JSON numbers, others strings as PostgreSQL prints them. Unbounded
+// ends are null, and empty ranges have null ends and bounds "empty".
+func RangeToJSON(r Range, rangeType string) (string, error) {
+	document := struct {
+		Lower  interface{} `json:"lower"`
+		Upper  interface{} `json:"upper"`
+		Bounds string      `json:"bounds"`
+	}{
+		Lower:  rangeBoundToJSON(r.Lower, rangeType),
+		Upper:  rangeBoundToJSON(r.Upper, rangeType),
+		Bounds: r.Bounds,
+	}
+
+	b, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func rangeBoundToJSON(bound *string, rangeType string) interface{} {
+	if bound == nil {
+		return nil
+	}
+	if IsNumericType(rangeSubtypes[rangeType]) {
+		return json.Number(*bound)
+	}
+	return *bound
+}
+
+// SplitRangeColumns replaces each range column configured in split_ranges
+// with two columns reading its lower and upper bounds, named after the
+// destination columns they are written to.
+func (c *Conversions) SplitRangeColumns(schema *Schema) error {
+	for tableName, columns := range c.SplitRanges {
+		table, err := schema.GetTable(strings.ToLower(tableName))
+		if err != nil {
+			return err
+		}
+
+		for columnName, split := range columns {
+			i, column, err := table.GetColumn(&Column{ActualName: columnName, NormalizedName: strings.ToLower(columnName)})
+			if err != nil {
+				return fmt.Errorf("failed to find column %s.%s: %s", tableName, columnName, err)
+			}
+			if !IsRangeType(column.Type) {
+				return fmt.Errorf("column %s.%s is not a range", tableName, columnName)
+			}
+			if split.Lower == "" || split.Upper == "" {
+				return fmt.Errorf("column %s.%s needs a lower and an upper column", tableName, columnName)
+			}
+
+			table.Columns = append(table.Columns[:i], table.Columns[i+1:]...)
+			table.Columns = append(table.Columns,
+				rangeBoundColumn(column, "lower", split.Lower),
+				rangeBoundColumn(column, "upper", split.Upper))
+		}
+
+		sort.SliceStable(table.Columns, func(i, j int) bool {
+			return table.Columns[i].NormalizedName < table.Columns[j].NormalizedName
+		})
+	}
+
+	return nil
+}
+
+func rangeBoundColumn(column *Column, bound, name string) *Column {
+	subtype := rangeSubtypes[column.Type]
+
+	boundColumn := &Column{
+		ActualName:     name,
+		NormalizedName: strings.ToLower(name),
+		Type:           subtype,
+		SplitFrom:      column,
+		Bound:          bound,
+		// unbounded ends read as NULL
+		IsNullable: true,
+	}
+	if IsTimeType(subtype) {
+		boundColumn.DatetimePrecision = PostgreSQLDatetimePrecision
+	}
+	return boundColumn
+}
+
+// GetRangeBoundsLossRowCount counts the rows of table whose values in the
+// src range column cannot be told apart once split into two columns: empty
+// ranges, and ranges with bounds other than [).
+func GetRangeBoundsLossRowCount(db DB, table *Table, src *Column, debug map[string]bool) (int64, error) {
+	column := db.QuoteIdentifier(src.ActualName)
+	stmt := fmt.Sprintf("SELECT count(1) FROM %s WHERE isempty(%s) OR NOT (lower_inc(%s) OR lower_inf(%s)) OR upper_inc(%s)",
+		db.QuoteTable(table.ActualName), column, column, column, column)
+	if debug["sql"] {
+		fmt.Println("DEBUG GetRangeBoundsLossRowCount SQL:", stmt)
+	}
+
+	var count int64
+	if err := db.DB().QueryRow(stmt).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,266 @@
+package pg2mysql
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Formats the validate and verify commands can report in.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatJUnit = "junit"
+)
+
+// VerificationResult is the outcome of verifying one table.
+type VerificationResult struct {
+	TableName       string   `json:"table_name" yaml:"table_name"`
+	MissingRowCount int64    `json:"missing_row_count" yaml:"missing_row_count"`
+	MissingIDs      []string `json:"missing_ids,omitempty" yaml:"missing_ids,omitempty"`
+	ExtraRowCount   int64    `json:"extra_row_count" yaml:"extra_row_count"`
+	ExtraIDs        []string `json:"extra_ids,omitempty" yaml:"extra_ids,omitempty"`
+	ChangedIDs      []string `json:"changed_ids,omitempty" yaml:"changed_ids,omitempty"`
+	SchemaProblems  []string `json:"schema_problems,omitempty" yaml:"schema_problems,omitempty"`
+	Error           string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// VerificationCollector is a VerifierWatcher gathering the result of each
+// table, for reports written once verification is over. Events are passed
+// on to Watcher, if set.
+type VerificationCollector struct {
+	Results []VerificationResult
+	Watcher VerifierWatcher
+}
+
+func NewVerificationCollector() *VerificationCollector {
+	return &VerificationCollector{}
+}
+
+func (c *VerificationCollector) TableVerificationDidStart(tableName string) {
+	if c.Watcher != nil {
+		c.Watcher.TableVerificationDidStart(tableName)
+	}
+}
+
+func (c *VerificationCollector) TableVerificationDidFinish(tableName string, missingRows int64, missingIDs []string, extraRows int64, extraIDs []string) {
+	c.Results = append(c.Results, VerificationResult{
+		TableName:       tableName,
+		MissingRowCount: missingRows,
+		MissingIDs:      missingIDs,
+		ExtraRowCount:   extraRows,
+		ExtraIDs:        extraIDs,
+	})
+	if c.Watcher != nil {
+		c.Watcher.TableVerificationDidFinish(tableName, missingRows, missingIDs, extraRows, extraIDs)
+	}
+}
+
+func (c *VerificationCollector) TableVerificationDidFinishWithError(tableName string, err error) {
+	c.Results = append(c.Results, VerificationResult{
+		TableName: tableName,
+		Error:     err.Error(),
+	})
+	if c.Watcher != nil {
+		c.Watcher.TableVerificationDidFinishWithError(tableName, err)
+	}
+}
+
+// TableVerificationDidFindSchemaProblems adds problems to the result of a
+// table whose rows were compared, or records a result of its own.
+func (c *VerificationCollector) TableVerificationDidFindSchemaProblems(tableName string, problems []string) {
+	if n := len(c.Results); n > 0 && c.Results[n-1].TableName == tableName {
+		c.Results[n-1].SchemaProblems = problems
+	} else {
+		c.Results = append(c.Results, VerificationResult{
+			TableName:      tableName,
+			SchemaProblems: problems,
+		})
+	}
+	if c.Watcher != nil {
+		c.Watcher.TableVerificationDidFindSchemaProblems(tableName, problems)
+	}
+}
+
+func (c *VerificationCollector) TableVerificationDidFindDifferences(tableName string, missingIDs, extraIDs, changedIDs []string) {
+	for i := len(c.Results) - 1; i >= 0; i-- {
+		if c.Results[i].TableName == tableName {
+			c.Results[i].ChangedIDs = changedIDs
+			break
+		}
+	}
+	if c.Watcher != nil {
+		c.Watcher.TableVerificationDidFindDifferences(tableName, missingIDs, extraIDs, changedIDs)
+	}
+}
+
+func (c *VerificationCollector) TableVerificationDidFindColumnDiffs(tableName string, diffs []ColumnDiff) {
+	if c.Watcher != nil {
+		c.Watcher.TableVerificationDidFindColumnDiffs(tableName, diffs)
+	}
+}
+
+// WriteValidationReport writes results to w in a json, yaml or junit
+// format.
+func WriteValidationReport(w io.Writer, format string, results []ValidationResult) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, map[string]interface{}{"tables": nonNil(results)})
+	case FormatYAML:
+		return writeYAML(w, map[string]interface{}{"tables": nonNil(results)})
+	case FormatJUnit:
+		suite := junitTestSuite{Name: "validate"}
+		for _, result := range results {
+			failures := append([]string(nil), result.SchemaProblems...)
+			if result.IncompatibleRowCount > 0 {
+				failures = append(failures, incompatibleRowsMessage(result))
+			}
+			for _, finding := range result.Findings {
+				if finding.Warning {
+					continue
+				}
+				failures = append(failures, fmt.Sprintf("%s: %s, %d rows", finding.ColumnName, finding.Rule, finding.RowCount))
+			}
+			for _, collision := range result.UniqueCollisions {
+				failures = append(failures, fmt.Sprintf("%d rows collide on unique index %s with key %q", collision.RowCount, collision.IndexName, collision.Key))
+			}
+			suite.add(result.TableName, failures, result.Error)
+		}
+		return writeJUnit(w, suite)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// WriteVerificationReport writes results to w in a json, yaml or junit
+// format.
+func WriteVerificationReport(w io.Writer, format string, results []VerificationResult) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, map[string]interface{}{"tables": nonNilVerification(results)})
+	case FormatYAML:
+		return writeYAML(w, map[string]interface{}{"tables": nonNilVerification(results)})
+	case FormatJUnit:
+		suite := junitTestSuite{Name: "verify"}
+		for _, result := range results {
+			failures := append([]string(nil), result.SchemaProblems...)
+			if result.MissingRowCount > 0 {
+				message := fmt.Sprintf("%d rows missing", result.MissingRowCount)
+				if len(result.MissingIDs) > 0 {
+					message += fmt.Sprintf(" with IDs %s", strings.Join(result.MissingIDs, ","))
+				}
+				failures = append(failures, message)
+			}
+			if len(result.ChangedIDs) > 0 {
+				failures = append(failures, fmt.Sprintf("%d rows changed with IDs %s", len(result.ChangedIDs), strings.Join(result.ChangedIDs, ",")))
+			}
+			if result.ExtraRowCount > 0 {
+				message := fmt.Sprintf("%d extra rows", result.ExtraRowCount)
+				if len(result.ExtraIDs) > 0 {
+					message += fmt.Sprintf(" with IDs %s", strings.Join(result.ExtraIDs, ","))
+				}
+				failures = append(failures, message)
+			}
+			suite.add(result.TableName, failures, result.Error)
+		}
+		return writeJUnit(w, suite)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func incompatibleRowsMessage(result ValidationResult) string {
+	if len(result.IncompatibleRowIDs) > 0 {
+		return fmt.Sprintf("%d incompatible rows with IDs %v", result.IncompatibleRowCount, result.IncompatibleRowIDs)
+	}
+	return fmt.Sprintf("%d incompatible rows", result.IncompatibleRowCount)
+}
+
+// nonNil returns an empty slice for nil results, so that reports list no
+// tables rather than a null.
+func nonNil(results []ValidationResult) []ValidationResult {
+	if results == nil {
+		return []ValidationResult{}
+	}
+	return results
+}
+
+func nonNilVerification(results []VerificationResult) []VerificationResult {
+	if results == nil {
+		return []VerificationResult{}
+	}
+	return results
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode json: %s", err)
+	}
+	return nil
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	bs, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode yaml: %s", err)
+	}
+	_, err = w.Write(bs)
+	return err
+}
+
+// junitTestSuite reports each table as a test case, failed when problems
+// were found in it and errored when it could not be checked.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (s *junitTestSuite) add(tableName string, failures []string, errorMessage string) {
+	testCase := junitTestCase{ClassName: s.Name, Name: tableName}
+	if len(failures) > 0 {
+		testCase.Failure = &junitMessage{Message: failures[0], Text: strings.Join(failures, "\n")}
+		s.Failures++
+	}
+	if errorMessage != "" {
+		testCase.Error = &junitMessage{Message: errorMessage}
+		s.Errors++
+	}
+	s.Tests++
+	s.TestCases = append(s.TestCases, testCase)
+}
+
+func writeJUnit(w io.Writer, suite junitTestSuite) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suite); err != nil {
+		return fmt.Errorf("failed to encode junit: %s", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
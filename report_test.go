@@ -0,0 +1,136 @@
+package pg2mysql_test
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"pg2mysql"
+)
+
+var _ = Describe("Reports", func() {
+	var results []pg2mysql.ValidationResult
+
+	BeforeEach(func() {
+		results = []pg2mysql.ValidationResult{
+			{TableName: "table_with_id", IncompatibleRowIDs: []int{3}, IncompatibleRowCount: 1},
+			{TableName: "table_without_id"},
+		}
+	})
+
+	Describe("WriteValidationReport", func() {
+		It("writes the results as json", func() {
+			var out bytes.Buffer
+			err := pg2mysql.WriteValidationReport(&out, pg2mysql.FormatJSON, results)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(MatchJSON(`{"tables": [
+				{"table_name": "table_with_id", "incompatible_row_ids": [3], "incompatible_row_count": 1},
+				{"table_name": "table_without_id", "incompatible_row_count": 0}
+			]}`))
+		})
+
+		It("writes the results as yaml", func() {
+			var out bytes.Buffer
+			err := pg2mysql.WriteValidationReport(&out, pg2mysql.FormatYAML, results)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(MatchYAML(`tables:
+- table_name: table_with_id
+  incompatible_row_ids: [3]
+  incompatible_row_count: 1
+- table_name: table_without_id
+  incompatible_row_count: 0
+`))
+		})
+
+		It("writes a junit test case per table", func() {
+			var out bytes.Buffer
+			err := pg2mysql.WriteValidationReport(&out, pg2mysql.FormatJUnit, results)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring(`<testsuite name="validate" tests="2" failures="1" errors="0">`))
+			Expect(out.String()).To(ContainSubstring(`<testcase classname="validate" name="table_with_id">`))
+			Expect(out.String()).To(ContainSubstring(`<failure message="1 incompatible rows with IDs [3]">`))
+			Expect(out.String()).To(ContainSubstring(`<testcase classname="validate" name="table_without_id"></testcase>`))
+		})
+
+		It("reports tables that failed to validate as junit errors", func() {
+			results = append(results, pg2mysql.ValidationResult{TableName: "table_failing", Error: "some-error"})
+
+			var out bytes.Buffer
+			err := pg2mysql.WriteValidationReport(&out, pg2mysql.FormatJUnit, results)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring(`<testsuite name="validate" tests="3" failures="1" errors="1">`))
+			Expect(out.String()).To(ContainSubstring(`<error message="some-error"></error>`))
+		})
+
+		It("rejects unknown formats", func() {
+			err := pg2mysql.WriteValidationReport(&bytes.Buffer{}, "csv", results)
+			Expect(err).To(MatchError(`unknown format "csv"`))
+		})
+	})
+
+	Describe("WriteVerificationReport", func() {
+		var collector *pg2mysql.VerificationCollector
+
+		BeforeEach(func() {
+			collector = pg2mysql.NewVerificationCollector()
+			collector.TableVerificationDidStart("table_with_id")
+			collector.TableVerificationDidFinish("table_with_id", 1, []string{"3"}, 1, []string{"9"})
+			collector.TableVerificationDidStart("table_without_id")
+			collector.TableVerificationDidFinishWithError("table_without_id", errors.New("some-error"))
+		})
+
+		It("writes the missing and extra rows and errors as json", func() {
+			var out bytes.Buffer
+			err := pg2mysql.WriteVerificationReport(&out, pg2mysql.FormatJSON, collector.Results)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(MatchJSON(`{"tables": [
+				{"table_name": "table_with_id", "missing_row_count": 1, "missing_ids": ["3"], "extra_row_count": 1, "extra_ids": ["9"]},
+				{"table_name": "table_without_id", "missing_row_count": 0, "extra_row_count": 0, "error": "some-error"}
+			]}`))
+		})
+
+		It("reports errors as junit errors", func() {
+			var out bytes.Buffer
+			err := pg2mysql.WriteVerificationReport(&out, pg2mysql.FormatJUnit, collector.Results)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring(`<testsuite name="verify" tests="2" failures="1" errors="1">`))
+			Expect(out.String()).To(ContainSubstring(`<failure message="1 rows missing with IDs 3">`))
+			Expect(out.String()).To(ContainSubstring("1 extra rows with IDs 9"))
+			Expect(out.String()).To(ContainSubstring(`<error message="some-error"></error>`))
+		})
+
+		It("reports tables missing from either side as junit failures", func() {
+			collector.TableVerificationDidStart("table_only_in_mysql")
+			collector.TableVerificationDidFindSchemaProblems("table_only_in_mysql", []string{"table is missing from the source"})
+
+			var out bytes.Buffer
+			err := pg2mysql.WriteVerificationReport(&out, pg2mysql.FormatJUnit, collector.Results)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring(`<testsuite name="verify" tests="3" failures="2" errors="1">`))
+			Expect(out.String()).To(ContainSubstring(`<failure message="table is missing from the source">`))
+		})
+
+		It("adds schema problems to the result of a table whose rows were compared", func() {
+			collector.TableVerificationDidStart("table_with_types")
+			collector.TableVerificationDidFinish("table_with_types", 0, nil, 0, nil)
+			collector.TableVerificationDidFindSchemaProblems("table_with_types", []string{"column a cannot be converted from text to int: too long"})
+
+			Expect(collector.Results).To(HaveLen(3))
+			Expect(collector.Results[2].TableName).To(Equal("table_with_types"))
+			Expect(collector.Results[2].SchemaProblems).To(Equal([]string{"column a cannot be converted from text to int: too long"}))
+		})
+
+		It("reports the changed rows found by key ranges", func() {
+			collector.TableVerificationDidFindDifferences("table_with_id", nil, []string{"9"}, []string{"3"})
+
+			var out bytes.Buffer
+			err := pg2mysql.WriteVerificationReport(&out, pg2mysql.FormatJSON, collector.Results)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(MatchJSON(`{"tables": [
+				{"table_name": "table_with_id", "missing_row_count": 1, "missing_ids": ["3"], "extra_row_count": 1, "extra_ids": ["9"], "changed_ids": ["3"]},
+				{"table_name": "table_without_id", "missing_row_count": 0, "extra_row_count": 0, "error": "some-error"}
+			]}`))
+		})
+	})
+})
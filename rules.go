@@ -0,0 +1,303 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Verdict tells how values of a source column survive in a destination
+// column.
+type Verdict string
+
+const (
+	// VerdictExact columns hold the same values in the same representation.
+	VerdictExact Verdict = "exact"
+	// VerdictLossless columns are converted, but every value survives.
+	VerdictLossless Verdict = "lossless"
+	// VerdictLossy columns may lose some values or part of them, so their
+	// rows are checked.
+	VerdictLossy Verdict = "lossy"
+	// VerdictIncompatible columns cannot hold any value of the source, so
+	// every row with a value is reported.
+	VerdictIncompatible Verdict = "incompatible"
+)
+
+// AnyType matches every data type in the key of a type rule.
+const AnyType = "*"
+
+// Assessment is the outcome of a type rule for a pair of columns. Check is
+// an optional SQL predicate selecting the source rows that cannot be
+// stored, with {column} standing for the source column.
+type Assessment struct {
+	Verdict Verdict
+	Reason  string
+	Check   string
+
+	// checks builds the checks of built-in rules checking more than the
+	// length of values
+	checks checkBuilder
+}
+
+// Checked reports whether rows of the column pair need to be checked.
+func (a Assessment) Checked() bool {
+	return a.Verdict == VerdictLossy || a.Verdict == VerdictIncompatible
+}
+
+// TypeRule assesses a pair of columns. ok is false when the rule does not
+// apply to the pair, so that the next rule is tried.
+type TypeRule func(src, dst *Column) (assessment Assessment, ok bool)
+
+type typePair struct {
+	src, dst string
+
+	// override is set for the rules of the config and those registered,
+	// which are tried before the built-in rules
+	override bool
+}
+
+// TypeRules is a registry of type rules keyed by source and destination
+// data type, either of which may be AnyType.
+type TypeRules map[typePair][]TypeRule
+
+// DefaultTypeRules holds the built-in rules, and any registered with
+// RegisterTypeRule.
+var DefaultTypeRules = builtinTypeRules()
+
+// RegisterTypeRule adds a rule for a pair of data types to
+// DefaultTypeRules, ahead of the rules already registered for the pair and
+// of every built-in rule.
+func RegisterTypeRule(srcType, dstType string, rule TypeRule) {
+	DefaultTypeRules.override(srcType, dstType, rule)
+}
+
+func (r TypeRules) add(srcType, dstType string, rule TypeRule) {
+	key := typePair{srcType, dstType, false}
+	r[key] = append(r[key], rule)
+}
+
+// override adds a rule tried before the built-in rules, and ahead of the
+// other overriding rules for the pair.
+func (r TypeRules) override(srcType, dstType string, rule TypeRule) {
+	key := typePair{srcType, dstType, true}
+	r[key] = append([]TypeRule{rule}, r[key]...)
+}
+
+// Assess returns the assessment of the first rule applying to the pair of
+// columns, trying rules for both data types first, then those for the
+// source type, those for the destination type, and finally the fallback.
+// Rules of the config and registered rules are all tried before any
+// built-in rule, so that they override the built-in rules of either type.
+func (r TypeRules) Assess(src, dst *Column) Assessment {
+	for _, override := range []bool{true, false} {
+		keys := []typePair{
+			{src.Type, dst.Type, override},
+			{src.Type, AnyType, override},
+			{AnyType, dst.Type, override},
+			{AnyType, AnyType, override},
+		}
+		for _, key := range keys {
+			for _, rule := range r[key] {
+				if assessment, ok := rule(src, dst); ok {
+					return assessment
+				}
+			}
+		}
+	}
+	return Assessment{Verdict: VerdictLossy, Reason: "no rule applies, values are checked against the destination length"}
+}
+
+func (r TypeRules) clone() TypeRules {
+	clone := TypeRules{}
+	for key, rules := range r {
+		clone[key] = append([]TypeRule(nil), rules...)
+	}
+	return clone
+}
+
+// TypeRuleConfig declares a type rule in the config. It applies to every
+// column of its source and destination type, either of which may be "*".
+type TypeRuleConfig struct {
+	Source      string  `yaml:"source"`
+	Destination string  `yaml:"destination"`
+	Verdict     Verdict `yaml:"verdict"`
+	Reason      string  `yaml:"reason"`
+	Check       string  `yaml:"check"`
+}
+
+// TypeRules returns DefaultTypeRules overridden by the type rules of the
+// config.
+func (c *Conversions) TypeRules() (TypeRules, error) {
+	if !c.typeRulesLoaded {
+		c.typeRules, c.typeRulesErr = loadTypeRules(c.TypeRuleConfigs)
+		c.typeRulesLoaded = true
+	}
+	return c.typeRules, c.typeRulesErr
+}
+
+func loadTypeRules(configs []TypeRuleConfig) (TypeRules, error) {
+	rules := DefaultTypeRules.clone()
+
+	// later rules override earlier ones for the same pair of types
+	for _, config := range configs {
+		if config.Source == "" || config.Destination == "" {
+			return nil, fmt.Errorf("type rule needs a source and a destination type")
+		}
+
+		switch config.Verdict {
+		case VerdictExact, VerdictLossless, VerdictLossy, VerdictIncompatible:
+		default:
+			return nil, fmt.Errorf("type rule for %s to %s has unknown verdict %q", config.Source, config.Destination, config.Verdict)
+		}
+
+		assessment := Assessment{Verdict: config.Verdict, Reason: config.Reason, Check: config.Check}
+		if assessment.Reason == "" {
+			assessment.Reason = "declared in the config"
+		}
+		rules.override(config.Source, config.Destination, func(src, dst *Column) (Assessment, bool) {
+			return assessment, true
+		})
+	}
+
+	return rules, nil
+}
+
+// checkCondition expands the {column} placeholder of the check of an
+// assessment.
+func checkCondition(db DB, src *Column, check string) string {
+	return strings.Replace(check, "{column}", columnExpression(db, src), -1)
+}
+
+func builtinTypeRules() TypeRules {
+	rules := TypeRules{}
+
+	for _, srcType := range []string{"json", "jsonb"} {
+		rules.add(srcType, AnyType, lossy("documents are checked for validity and size", jsonChecks))
+	}
+	rules.add(AnyType, "json", lossy("values are checked for validity and size as JSON documents", jsonChecks))
+
+	rules.add("ARRAY", AnyType, lossy("arrays are converted and checked element by element", conversionChecks))
+	rules.add("interval", AnyType, func(src, dst *Column) (Assessment, bool) {
+		return Assessment{Verdict: VerdictLossy, Reason: "intervals are checked against the range of the destination", checks: conversionChecks}, convertsInterval(src, dst)
+	})
+	for _, srcType := range []string{"inet", "cidr", "macaddr"} {
+		rules.add(srcType, AnyType, lossy("addresses are converted and checked value by value", conversionChecks))
+	}
+	for srcType := range rangeSubtypes {
+		rules.add(srcType, AnyType, lossy("ranges are converted and checked value by value", jsonConversionChecks))
+	}
+
+	rules.add("USER-DEFINED", AnyType, userDefinedRule)
+
+	for _, srcType := range []string{"smallint", "integer", "bigint", "numeric", "real", "double precision"} {
+		rules.add(srcType, AnyType, numberRule)
+	}
+
+	for _, srcType := range []string{"bit", "bit varying"} {
+		rules.add(srcType, "bit", func(src, dst *Column) (Assessment, bool) {
+			if BitsFit(src, dst) {
+				return Assessment{Verdict: VerdictLossless, Reason: "bit strings fit the destination"}, true
+			}
+			return Assessment{Verdict: VerdictLossy, Reason: "bit strings are checked against the destination length", checks: bitChecks}, true
+		})
+	}
+
+	for _, srcType := range []string{"character varying", "character", "text"} {
+		rules.add(srcType, AnyType, func(src, dst *Column) (Assessment, bool) {
+			return Assessment{Verdict: VerdictLossy, Reason: "the destination character set cannot store supplementary characters"}, LosesSupplementaryCharacters(src, dst)
+		})
+	}
+
+	for _, srcType := range []string{"date", "timestamp with time zone", "timestamp without time zone", "time with time zone", "time without time zone"} {
+		rules.add(srcType, AnyType, timeRule)
+	}
+
+	rules.add("uuid", AnyType, func(src, dst *Column) (Assessment, bool) {
+		return Assessment{Verdict: VerdictLossless, Reason: "uuids are packed into 16 bytes"}, IsBinaryType(dst.Type) && dst.MaxChars == 16
+	})
+
+	rules.add(AnyType, AnyType, lengthRule)
+
+	return rules
+}
+
+func lossy(reason string, checks checkBuilder) TypeRule {
+	return func(src, dst *Column) (Assessment, bool) {
+		return Assessment{Verdict: VerdictLossy, Reason: reason, checks: checks}, true
+	}
+}
+
+func userDefinedRule(src, dst *Column) (Assessment, bool) {
+	switch {
+	case IsEnumType(src) && IsEnumType(dst):
+		return Assessment{Verdict: VerdictLossy, Reason: "labels are checked against the destination labels", checks: enumLabelChecks}, true
+	case IsEnumType(src):
+		return Assessment{Verdict: VerdictLossy, Reason: "labels are checked against the destination length", checks: enumLengthChecks}, true
+	case IsHstoreType(src):
+		return Assessment{Verdict: VerdictLossy, Reason: "hstores are converted and checked value by value", checks: jsonConversionChecks}, true
+	case convertsSpatial(src, dst):
+		if SpatialFits(src, dst) {
+			return Assessment{Verdict: VerdictLossless, Reason: "geometries fit the destination"}, true
+		}
+		return Assessment{Verdict: VerdictLossy, Reason: "geometries are checked for type, dimensions and SRID", checks: spatialChecks}, true
+	}
+	return Assessment{}, false
+}
+
+func numberRule(src, dst *Column) (Assessment, bool) {
+	switch {
+	case IsDecimalType(dst.Type):
+		if NumericFits(src, dst) {
+			return Assessment{Verdict: VerdictLossless, Reason: "numbers fit the destination precision and scale"}, true
+		}
+		return Assessment{Verdict: VerdictLossy, Reason: "numbers are checked against the destination precision and scale", checks: decimalChecks}, true
+	case IsIntegerType(dst.Type):
+		if IntegerFits(src, dst) {
+			return Assessment{Verdict: VerdictLossless, Reason: "integers fit the destination range"}, true
+		}
+		return Assessment{Verdict: VerdictLossy, Reason: "numbers are checked against the destination range", checks: integerChecks}, true
+	}
+	return Assessment{}, false
+}
+
+// timeRule leaves dates and times unchecked: the validator warns about lost
+// fractional seconds, and out of range values follow their policy.
+func timeRule(src, dst *Column) (Assessment, bool) {
+	switch {
+	case !IsTimeType(dst.Type) && dst.Type != "date":
+		return Assessment{}, false
+	case LosesFractionalSeconds(src, dst):
+		return Assessment{Verdict: VerdictLossless, Reason: "fractional seconds beyond the destination precision are dropped"}, true
+	case src.Type == dst.Type:
+		return Assessment{Verdict: VerdictExact, Reason: "same type"}, true
+	}
+	return Assessment{Verdict: VerdictLossless, Reason: "dates and times are converted"}, true
+}
+
+// lengthRule compares the lengths of any other pair of columns.
+func lengthRule(src, dst *Column) (Assessment, bool) {
+	switch {
+	case src.MaxChars == 0 && dst.MaxChars == 0:
+		if src.Type == dst.Type {
+			return Assessment{Verdict: VerdictExact, Reason: "same type"}, true
+		}
+		return Assessment{Verdict: VerdictLossless, Reason: "neither type has a length limit"}, true
+	case src.MaxChars > 0 && dst.MaxChars > 0 && src.MaxChars <= dst.MaxChars:
+		if src.MaxChars == dst.MaxChars && sameTypeName(src.Type, dst.Type) {
+			return Assessment{Verdict: VerdictExact, Reason: "same type and length"}, true
+		}
+		return Assessment{Verdict: VerdictLossless, Reason: "the destination is at least as long"}, true
+	}
+	return Assessment{Verdict: VerdictLossy, Reason: "values are checked against the destination length"}, true
+}
+
+// sameTypeName reports whether a PostgreSQL and a MySQL data type name the
+// same type.
+func sameTypeName(srcType, dstType string) bool {
+	switch srcType {
+	case "character varying":
+		return dstType == "varchar"
+	case "character":
+		return dstType == "char"
+	}
+	return srcType == dstType
+}
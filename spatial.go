@@ -0,0 +1,140 @@
+package pg2mysql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PostGISGeographySRID is the SRID of geography columns declared without one.
+const PostGISGeographySRID = 4326
+
+// IsPostGISType reports whether a source column holds PostGIS geometries or
+// geographies.
+func IsPostGISType(c *Column) bool {
+	return c.Type == "USER-DEFINED" && (c.UDTName == "geometry" || c.UDTName == "geography")
+}
+
+// IsMySQLSpatialType reports whether a MySQL data type holds spatial values.
+func IsMySQLSpatialType(dataType string) bool {
+	switch dataType {
+	case "geometry", "point", "linestring", "polygon",
+		"multipoint", "multilinestring", "multipolygon", "geometrycollection":
+		return true
+	}
+	return false
+}
+
+// convertsSpatial reports whether values of the src column are copied to
+// the dst column as geometries.
+func convertsSpatial(src, dst *Column) bool {
+	return IsPostGISType(src) && IsMySQLSpatialType(dst.Type)
+}
+
+// ParseSpatialColumnType returns the geometry type, upper case, and SRID
+// declared by a PostGIS column type such as geometry(Point,4326). The type
+// is empty for columns accepting any geometry.
+func ParseSpatialColumnType(columnType string) (string, int64) {
+	var srid int64
+	if strings.HasPrefix(columnType, "geography") {
+		srid = PostGISGeographySRID
+	}
+
+	open := strings.IndexByte(columnType, '(')
+	if open < 0 || !strings.HasSuffix(columnType, ")") {
+		return "", srid
+	}
+
+	modifiers := strings.Split(columnType[open+1:len(columnType)-1], ",")
+	if len(modifiers) > 1 {
+		if declared, err := strconv.ParseInt(strings.TrimSpace(modifiers[1]), 10, 64); err == nil {
+			srid = declared
+		}
+	}
+
+	return strings.ToUpper(strings.TrimSpace(modifiers[0])), srid
+}
+
+// SpatialSRID returns the SRID values of the src column are written to the
+// dst column with: the SRID dst restricts its values to, or else the one src
+// declares. ok is false when neither declares one, and each value keeps its
+// own SRID.
+func SpatialSRID(src, dst *Column) (srid int64, ok bool) {
+	if dst.SRID != nil {
+		return *dst.SRID, true
+	}
+	_, srid = ParseSpatialColumnType(src.ColumnType)
+	return srid, srid != 0
+}
+
+// spatialExpression returns the expression reading geometries of the src
+// column as WKB, preceded by their SRID as 4 big endian bytes when they
+// keep their own SRID in the dst column.
+func spatialExpression(db DB, src, dst *Column) string {
+	wkb := fmt.Sprintf("ST_AsBinary(%s, 'NDR')", columnExpression(db, src))
+	if _, ok := SpatialSRID(src, dst); ok {
+		return wkb
+	}
+	return fmt.Sprintf("int4send(ST_SRID(CAST(%s AS geometry))) || %s", columnExpression(db, src), wkb)
+}
+
+// acceptedGeometryTypes returns the geometry types, as named by PostGIS's
+// GeometryType, that a MySQL spatial column accepts, or nil when it
+// accepts them all.
+func acceptedGeometryTypes(dst *Column) []string {
+	switch dst.Type {
+	case "geometry":
+		return nil
+	case "geometrycollection":
+		return []string{"GEOMETRYCOLLECTION", "MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON"}
+	}
+	return []string{strings.ToUpper(dst.Type)}
+}
+
+// SpatialFits reports whether every value of the src column is accepted by
+// the dst column: the source declares a two dimensional geometry type the
+// destination accepts, and the SRID the destination restricts values to,
+// if any.
+func SpatialFits(src, dst *Column) bool {
+	geometryType, srid := ParseSpatialColumnType(src.ColumnType)
+	if geometryType == "" || geometryType == "GEOMETRY" && dst.Type != "geometry" ||
+		strings.HasSuffix(geometryType, "Z") || strings.HasSuffix(geometryType, "M") {
+		return false
+	}
+	if dst.SRID != nil && *dst.SRID != srid {
+		return false
+	}
+
+	accepted := acceptedGeometryTypes(dst)
+	if accepted == nil {
+		return true
+	}
+	for _, t := range accepted {
+		if t == geometryType {
+			return true
+		}
+	}
+	return false
+}
+
+// spatialCondition selects geometries the dst column cannot accept: those
+// with a Z or M dimension, of a type the column does not hold, or with an
+// SRID other than the one they are written with, if not their own.
+func spatialCondition(db DB, src, dst *Column) string {
+	geometry := fmt.Sprintf("CAST(%s AS geometry)", columnExpression(db, src))
+
+	conditions := []string{fmt.Sprintf("ST_NDims(%s) > 2", geometry)}
+	if srid, ok := SpatialSRID(src, dst); ok {
+		conditions = append(conditions, fmt.Sprintf("ST_SRID(%s) <> %d", geometry, srid))
+	}
+
+	if accepted := acceptedGeometryTypes(dst); accepted != nil {
+		quoted := make([]string, len(accepted))
+		for i, t := range accepted {
+			quoted[i] = quoteLiteral(t)
+		}
+		conditions = append(conditions, fmt.Sprintf("GeometryType(%s) NOT IN (%s)", geometry, strings.Join(quoted, ",")))
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
@@ -0,0 +1,44 @@
+package pg2mysql
+
+import (
+	"strings"
+	"time"
+)
+
+// Location returns the zone timestamps are converted to, or nil when the
+// session zone of each connection is preserved. TimeZone is either
+// "session" (the default), "UTC" or a zone name such as "Europe/Berlin".
+func (c *Conversions) Location() (*time.Location, error) {
+	if !c.locationLoaded {
+		c.location, c.locationErr = loadLocation(c.TimeZone)
+		c.locationLoaded = true
+	}
+	return c.location, c.locationErr
+}
+
+func loadLocation(timeZone string) (*time.Location, error) {
+	switch strings.ToLower(timeZone) {
+	case "", "session":
+		return nil, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	return time.LoadLocation(timeZone)
+}
+
+// convertTime applies the time zone policy to a timestamp read from the src
+// column. Instants are moved into the configured zone, while timestamps
+// without a zone keep their wall clock so the driver does not shift them
+// when binding them in that zone.
+func (c *Conversions) convertTime(src *Column, t time.Time) (time.Time, error) {
+	loc, err := c.Location()
+	if err != nil || loc == nil {
+		return t, err
+	}
+
+	if src.Type == "timestamp with time zone" {
+		return t.In(loc), nil
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc), nil
+}
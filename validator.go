@@ -2,22 +2,25 @@ package pg2mysql
 
 import (
 	"fmt"
+	"os"
 )
 
 type Validator interface {
 	Validate() ([]ValidationResult, error)
 }
 
-func NewValidator(src, dst DB, debug map[string]bool) Validator {
+func NewValidator(src, dst DB, conversions *Conversions, debug map[string]bool) Validator {
 	return &validator{
 		src: src,
 		dst: dst,
+		conversions: conversions,
       debug: debug,
 	}
 }
 
 type validator struct {
 	src, dst DB
+	conversions *Conversions
     debug map[string]bool
 }
 
@@ -27,6 +30,10 @@ func (v *validator) Validate() ([]ValidationResult, error) {
 		return nil, fmt.Errorf("failed to build source schema: %s", err)
 	}
 
+	if err = v.conversions.SplitRangeColumns(srcSchema); err != nil {
+		return nil, fmt.Errorf("failed to split range columns: %s", err)
+	}
+
 	dstSchema, err := BuildSchema(v.dst)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build destination schema: %s", err)
@@ -36,8 +43,13 @@ func (v *validator) Validate() ([]ValidationResult, error) {
          // return nil, fmt.Errorf("failed static analysis" )
      // }
 
+	rules, err := v.conversions.TypeRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed loading type rules: %s", err)
+	}
+
     if v.debug["schema"] {
-        DumpSchema(srcSchema, dstSchema, v.src, v.dst)
+        DumpSchema(srcSchema, dstSchema, v.src, v.dst, rules)
     }
 
     if v.debug["stop"] {
@@ -50,42 +62,110 @@ func (v *validator) Validate() ([]ValidationResult, error) {
         srcTable := srcSchema.Tables[tableName]
 		dstTable, err := dstSchema.GetTable(srcTable.NormalizedName)
 		if err != nil {
-            return nil, fmt.Errorf("failed to get table from destination schema: %s", err)
-        }
+			results = append(results, ValidationResult{
+				TableName:      srcTable.ActualName,
+				SchemaProblems: []string{"table is missing from the destination"},
+			})
+			continue
+		}
         if dstTable.ActualName != srcTable.ActualName {
-            fmt.Println( "Warning: Source table", srcTable.ActualName,
+            fmt.Fprintln(os.Stderr, "Warning: Source table", srcTable.ActualName,
                          "does not exist in the destination schema, but found", dstTable.ActualName, "instead.")
 		}
 
-		if srcTable.HasIDColumn(dstTable, v.debug) {
-			rowIDs, err := GetIncompatibleRowIDs(v.src, srcTable, dstTable, v.debug)
-			if err != nil {
-				return nil, fmt.Errorf("failed getting incompatible row ids: %s", err)
+		result, err := v.validateTable(rules, srcTable, dstTable)
+		if err != nil {
+			result = ValidationResult{
+				TableName: srcTable.ActualName,
+				Error:     err.Error(),
 			}
+		}
+		results = append(results, result)
+	}
 
-			results = append(results, ValidationResult{
-				TableName:            srcTable.ActualName,
-				IncompatibleRowIDs:   rowIDs,
-				IncompatibleRowCount: int64(len(rowIDs)),
+	return results, nil
+}
+
+// validateTable validates the rows of srcTable against dstTable. An error
+// fails this table only; the other tables are still validated.
+func (v *validator) validateTable(rules TypeRules, srcTable, dstTable *Table) (ValidationResult, error) {
+	var warnings []ColumnFinding
+	for _, srcColumn := range srcTable.Columns {
+		_, dstColumn, err := dstTable.GetColumn(srcColumn)
+		if err != nil || !LosesFractionalSeconds(srcColumn, dstColumn) {
+			continue
+		}
+
+		count, err := GetFractionalSecondsLossRowCount(v.src, srcTable, srcColumn, dstColumn, v.debug)
+		if err != nil {
+			return ValidationResult{}, fmt.Errorf("failed counting rows losing fractional seconds: %s", err)
+		}
+		if count > 0 {
+			warnings = append(warnings, ColumnFinding{
+				ColumnName: srcColumn.ActualName,
+				Rule:       RulePrecision,
+				RowCount:   count,
+				Warning:    true,
 			})
-		} else {
-			rowCount, err := GetIncompatibleRowCount(v.src, srcTable, dstTable, v.debug)
-			if err != nil {
-				return nil, fmt.Errorf("failed getting incompatible row count: %s", err)
-			}
+		}
+	}
 
-			results = append(results, ValidationResult{
-				TableName:            srcTable.ActualName,
-				IncompatibleRowCount: rowCount,
+	for _, srcColumn := range srcTable.Columns {
+		if srcColumn.Bound != "lower" {
+			continue
+		}
+
+		count, err := GetRangeBoundsLossRowCount(v.src, srcTable, srcColumn.SplitFrom, v.debug)
+		if err != nil {
+			return ValidationResult{}, fmt.Errorf("failed counting ranges losing their bounds: %s", err)
+		}
+		if count > 0 {
+			warnings = append(warnings, ColumnFinding{
+				ColumnName: srcColumn.SplitFrom.ActualName,
+				Rule:       RuleBounds,
+				RowCount:   count,
+				Warning:    true,
 			})
 		}
 	}
 
-	return results, nil
+	problems, checkable := GetSchemaProblems(rules, srcTable, dstTable)
+	if !checkable {
+		return ValidationResult{
+			TableName:      srcTable.ActualName,
+			Findings:       warnings,
+			SchemaProblems: problems,
+		}, nil
+	}
+
+	collisions, err := GetUniqueCollisions(v.src, srcTable, dstTable, v.debug)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("failed finding unique key collisions: %s", err)
+	}
+
+	withIDs := srcTable.HasIDColumn(dstTable, v.debug)
+	rowIDs, rowCount, findings, err := GetIncompatibleRows(v.src, v.conversions, srcTable, dstTable, withIDs, v.debug)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("failed getting incompatible rows: %s", err)
+	}
+
+	return ValidationResult{
+		TableName:            srcTable.ActualName,
+		IncompatibleRowIDs:   rowIDs,
+		IncompatibleRowCount: rowCount,
+		UniqueCollisions:     collisions,
+		Findings:             append(findings, warnings...),
+		SchemaProblems:       problems,
+	}, nil
+
 }
 
 type ValidationResult struct {
-	TableName            string
-	IncompatibleRowIDs   []int
-	IncompatibleRowCount int64
+	TableName            string            `json:"table_name" yaml:"table_name"`
+	IncompatibleRowIDs   []int             `json:"incompatible_row_ids,omitempty" yaml:"incompatible_row_ids,omitempty"`
+	IncompatibleRowCount int64             `json:"incompatible_row_count" yaml:"incompatible_row_count"`
+	UniqueCollisions     []UniqueCollision `json:"unique_collisions,omitempty" yaml:"unique_collisions,omitempty"`
+	Findings             []ColumnFinding   `json:"findings,omitempty" yaml:"findings,omitempty"`
+	SchemaProblems       []string          `json:"schema_problems,omitempty" yaml:"schema_problems,omitempty"`
+	Error                string            `json:"error,omitempty" yaml:"error,omitempty"`
 }
@@ -1,6 +1,8 @@
 package pg2mysql_test
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"pg2mysql"
@@ -21,6 +23,8 @@ var _ = Describe("Validator", func() {
 			"127.0.0.1",
 			3306,
 			false,
+			nil,
+			"",
 		)
 
 		err := mysql.Open()
@@ -37,7 +41,7 @@ var _ = Describe("Validator", func() {
 		err = pg.Open()
 		Expect(err).NotTo(HaveOccurred())
 
-		validator = pg2mysql.NewValidator(pg, mysql)
+		validator = pg2mysql.NewValidator(pg, mysql, &pg2mysql.Conversions{}, nil)
 	})
 
 	AfterEach(func() {
@@ -52,11 +56,11 @@ var _ = Describe("Validator", func() {
 			result, err := validator.Validate()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(HaveLen(3))
-			Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+			Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
 				TableName: "table_with_id",
 			}))
 
-			Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+			Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
 				TableName: "table_without_id",
 			}))
 		})
@@ -74,11 +78,11 @@ var _ = Describe("Validator", func() {
 				result, err := validator.Validate()
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result).To(HaveLen(3))
-				Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
 					TableName: "table_with_id",
 				}))
 
-				Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
 					TableName: "table_without_id",
 				}))
 			})
@@ -98,16 +102,33 @@ var _ = Describe("Validator", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(result).To(HaveLen(3))
-				Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
 					TableName:            "table_with_id",
 					IncompatibleRowIDs:   []int{3},
 					IncompatibleRowCount: 1,
 				}))
 
-				Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
 					TableName: "table_without_id",
 				}))
 			})
+
+			It("reports the column, rule and size that failed", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(resultFor(result, "table_with_id").Findings).To(Equal([]pg2mysql.ColumnFinding{
+					{
+						ColumnName: "name",
+						Rule:       pg2mysql.RuleLength,
+						RowIDs:     []int{3},
+						RowCount:   1,
+						MaxSize:    293,
+						Limit:      255,
+						Samples:    []string{"some-name-that-is-too-long-for-mysql-xxx..."},
+					},
+				}))
+			})
 		})
 
 		Context("when there is incompatible data in postgres in a table without an 'id' column", func() {
@@ -123,16 +144,790 @@ var _ = Describe("Validator", func() {
 				result, err := validator.Validate()
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result).To(HaveLen(3))
-				Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
 					TableName: "table_with_id",
 				}))
 
-				Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
 					TableName:            "table_without_id",
 					IncompatibleRowIDs:   nil,
 					IncompatibleRowCount: 1,
 				}))
 			})
 		})
+
+		Context("when tables and columns are named with reserved words or mixed case", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE "order" (id integer NOT NULL, "group" text NOT NULL, "user" text, "MixedCase" text)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE `order` (`id` integer NOT NULL, `group` varchar(10) NOT NULL, `user` varchar(255), `MixedCase` varchar(255))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO "order" (id, "group", "user", "MixedCase") VALUES (1, 'short', 'some-user', 'Some-Value'), (2, 'some-group-that-is-too-long', 'some-user', 'Some-Value')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE "order"`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE `order`")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a result", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(HaveLen(4))
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "order",
+					IncompatibleRowIDs:   []int{2},
+					IncompatibleRowCount: 1,
+				}))
+			})
+		})
+
+		Context("when there are json columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_json (id integer NOT NULL, doc json, bdoc jsonb)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_json (`id` integer NOT NULL, `doc` json, `bdoc` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_json (id, doc, bdoc) VALUES
+					(1, '{"b": 2, "a": [1, 2.5, "x"]}', '{"b": 2, "a": [1, 2.5, "x"]}'),
+					(2, NULL, NULL),
+					(3, '{"a": "\u0000"}', NULL),
+					(4, '{"a": 1e400}', NULL),
+					(5, NULL, json_build_object('a', repeat('x', 5000000))::jsonb)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_json`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_json")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports documents MySQL cannot store", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_json",
+					IncompatibleRowIDs:   []int{3, 4, 5},
+					IncompatibleRowCount: 3,
+				}))
+			})
+		})
+
+		Context("when there are array columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_array (id integer NOT NULL, tags text[], nums integer[])`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_array (`id` integer NOT NULL, `tags` varchar(20), `nums` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_array (id, tags, nums) VALUES
+					(1, '{a,"b c"}', '{1,2}'),
+					(2, '{a,NULL}', '{1,NULL}'),
+					(3, '{{a,b},{c,d}}', '{{1,2},{3,4}}'),
+					(4, NULL, '{{1,2},{3,NULL}}'),
+					(5, '{"a,b",c}', NULL),
+					(6, '{aaaaaaaaaa,bbbbbbbbbb}', NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_array`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_array")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports arrays that cannot be represented in the destination", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_array",
+					IncompatibleRowIDs:   []int{2, 3, 5, 6},
+					IncompatibleRowCount: 4,
+				}))
+			})
+		})
+
+		Context("when there are enum columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TYPE mood AS ENUM ('sad', 'ok', 'very happy'); CREATE TABLE table_with_enum (id integer NOT NULL, mood mood)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_enum (`id` integer NOT NULL, `mood` enum('sad','ok','happy'))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_enum (id, mood) VALUES (1, 'sad'), (2, 'very happy'), (3, NULL), (4, 'very happy')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_enum; DROP TYPE mood`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_enum")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports rows holding labels missing from the destination", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_enum",
+					IncompatibleRowIDs:   []int{2, 4},
+					IncompatibleRowCount: 2,
+				}))
+			})
+
+			It("accepts labels renamed in the config", func() {
+				conversions := &pg2mysql.Conversions{
+					EnumLabels: map[string]map[string]string{
+						"mood": {"very happy": "happy"},
+					},
+				}
+				result, err := pg2mysql.NewValidator(pg, mysql, conversions, nil).Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName: "table_with_enum",
+				}))
+			})
+		})
+
+		Context("when numeric columns have a larger precision or scale than the destination", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_numeric (id integer NOT NULL, amount numeric(20,6), ratio double precision, quantity integer)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_numeric (`id` integer NOT NULL, `amount` decimal(10,2), `ratio` decimal(6,3), `quantity` decimal(12,2))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_numeric (id, amount, ratio, quantity) VALUES
+					(1, 12345678.12, 1.5, 2147483647),
+					(2, 123456789, NULL, NULL),
+					(3, 1.234, NULL, NULL),
+					(4, 99999999.999, NULL, NULL),
+					(5, NULL, 1000.5, NULL),
+					(6, NULL, 'Infinity', NULL),
+					(7, -12345678.12, -999.999, -2147483648)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_numeric`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_numeric")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports rows that would overflow or lose fractional digits", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_numeric",
+					IncompatibleRowIDs:   []int{2, 3, 4, 5, 6},
+					IncompatibleRowCount: 5,
+				}))
+			})
+		})
+
+		Context("when time columns have fractional seconds", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_fsp (id integer NOT NULL, micros timestamp(6), millis timestamp(6))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_fsp (`id` integer NOT NULL, `micros` datetime(6), `millis` datetime(3))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_fsp (id, micros, millis) VALUES
+					(1, '2021-01-01 10:00:00.123456', '2021-01-01 10:00:00.123'),
+					(2, '2021-01-01 10:00:00.5', '2021-01-01 10:00:00.123456'),
+					(3, NULL, '2021-01-01 10:00:00.000001')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_fsp`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_fsp")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the rows losing fractional seconds as warnings", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resultFor(result, "table_with_fsp").IncompatibleRowCount).To(BeZero())
+				Expect(resultFor(result, "table_with_fsp").Findings).To(Equal([]pg2mysql.ColumnFinding{
+					{ColumnName: "millis", Rule: pg2mysql.RulePrecision, RowCount: 2, Warning: true},
+				}))
+			})
+
+			It("counts the rows losing fractional seconds in each column", func() {
+				srcSchema, err := pg2mysql.BuildSchema(pg)
+				Expect(err).NotTo(HaveOccurred())
+				dstSchema, err := pg2mysql.BuildSchema(mysql)
+				Expect(err).NotTo(HaveOccurred())
+
+				srcTable, err := srcSchema.GetTable("table_with_fsp")
+				Expect(err).NotTo(HaveOccurred())
+				dstTable, err := dstSchema.GetTable("table_with_fsp")
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := map[string]int64{"micros": 0, "millis": 2}
+				for i, srcColumn := range srcTable.Columns {
+					if _, ok := expected[srcColumn.NormalizedName]; !ok {
+						continue
+					}
+					dstColumn := dstTable.Columns[i]
+
+					var count int64
+					if pg2mysql.LosesFractionalSeconds(srcColumn, dstColumn) {
+						count, err = pg2mysql.GetFractionalSecondsLossRowCount(pg, srcTable, srcColumn, dstColumn, nil)
+						Expect(err).NotTo(HaveOccurred())
+					}
+					Expect(count).To(Equal(expected[srcColumn.NormalizedName]), srcColumn.ActualName)
+				}
+			})
+		})
+
+		Context("when there are intervals that don't fit the destination", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_time_types (id integer NOT NULL, duration interval, elapsed interval, opens_at time with time zone, closes_at time(6), born_on date)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_time_types (`id` integer NOT NULL, `duration` bigint, `elapsed` time, `opens_at` time, `closes_at` time(3), `born_on` date)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_time_types (id, duration, elapsed) VALUES
+					(1, '1 day 02:00:00', '-838:59:59'),
+					(2, '1.5 seconds', '00:00:01'),
+					(3, '1 hour', '839:00:00'),
+					(4, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_time_types`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_time_types")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the rows whose intervals can't be converted", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_time_types",
+					IncompatibleRowIDs:   []int{2, 3},
+					IncompatibleRowCount: 2,
+				}))
+			})
+		})
+
+		Context("when there are intervals that don't fit a decimal destination", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_decimal_intervals (id integer NOT NULL, duration interval)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_decimal_intervals (`id` integer NOT NULL, `duration` decimal(8,2))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_decimal_intervals (id, duration) VALUES
+					(1, '1 day 00:00:00.25'),
+					(2, '1000000 seconds'),
+					(3, '00:00:01.005'),
+					(4, '100 years 00:00:00.000001')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_decimal_intervals`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_decimal_intervals")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the rows overflowing the precision or scale", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_decimal_intervals",
+					IncompatibleRowIDs:   []int{2, 3, 4},
+					IncompatibleRowCount: 3,
+				}))
+			})
+		})
+
+		Context("when there are network addresses and bit strings", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_network (id integer NOT NULL, address inet, network cidr, mac macaddr, flags varbit(8))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_network (`id` integer NOT NULL, `address` varbinary(16), `network` varchar(18), `mac` binary(6), `flags` bit(4))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_network (id, address, network, mac, flags) VALUES
+					(1, '192.168.0.1', '10.0.0.0/8', '08:00:2b:01:02:03', B'0101'),
+					(2, '10.0.0.1/8', NULL, NULL, NULL),
+					(3, '::1', '2001:db8::/32', NULL, B'10000'),
+					(4, NULL, NULL, NULL, B'00001111')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_network`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_network")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports addresses with prefixes and bit strings that are too long", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_network",
+					IncompatibleRowIDs:   []int{2, 3},
+					IncompatibleRowCount: 2,
+				}))
+			})
+		})
+
+		Context("when there are geometries", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE EXTENSION IF NOT EXISTS postgis`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`CREATE TABLE table_with_geometry (id integer NOT NULL, location geometry(Point,4326), shape geometry)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_geometry (`id` integer NOT NULL, `location` point, `shape` polygon)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_geometry (id, location, shape) VALUES
+					(1, ST_GeomFromText('POINT(1 2)', 4326), ST_GeomFromText('POLYGON((0 0,1 0,1 1,0 0))')),
+					(2, NULL, ST_GeomFromText('LINESTRING(0 0,1 1)')),
+					(3, NULL, ST_GeomFromText('POLYGON((0 0,1 0,1 1,0 0))', 4326)),
+					(4, NULL, ST_GeomFromText('POLYGON Z((0 0 0,1 0 0,1 1 0,0 0 0))')),
+					(5, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_geometry`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_geometry")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports geometry types and dimensions the destination can't accept", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_geometry",
+					IncompatibleRowIDs:   []int{2, 4},
+					IncompatibleRowCount: 2,
+				}))
+			})
+		})
+
+		Context("when there are ranges and hstores", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE EXTENSION IF NOT EXISTS hstore`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`CREATE TABLE table_with_ranges (id integer NOT NULL, period tstzrange, seats int4range, attrs hstore)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_ranges (`id` integer NOT NULL, `period_start` datetime, `period_end` datetime, `seats` json, `attrs` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_ranges (id, period, seats, attrs) VALUES
+					(1, '[2021-01-01 10:00:00+00,2021-01-02 10:00:00+00)', '[1,5)', 'a=>1, b=>NULL'),
+					(2, '[2021-01-01 10:00:00+00,2021-01-02 10:00:00+00]', 'empty', NULL),
+					(3, 'empty', NULL, NULL),
+					(4, '[2021-01-01 10:00:00+00,)', NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_ranges`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_ranges")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("validates ranges split into two columns", func() {
+				conversions := &pg2mysql.Conversions{
+					SplitRanges: map[string]map[string]pg2mysql.RangeColumns{
+						"table_with_ranges": {"period": {Lower: "period_start", Upper: "period_end"}},
+					},
+				}
+				result, err := pg2mysql.NewValidator(pg, mysql, conversions, nil).Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName: "table_with_ranges",
+				}))
+				Expect(resultFor(result, "table_with_ranges").Findings).To(ContainElement(pg2mysql.ColumnFinding{
+					ColumnName: "period", Rule: pg2mysql.RuleBounds, RowCount: 2, Warning: true,
+				}))
+			})
+
+			It("counts the ranges whose bounds split columns can't represent", func() {
+				srcSchema, err := pg2mysql.BuildSchema(pg)
+				Expect(err).NotTo(HaveOccurred())
+				srcTable, err := srcSchema.GetTable("table_with_ranges")
+				Expect(err).NotTo(HaveOccurred())
+				_, period, err := srcTable.GetColumn(&pg2mysql.Column{ActualName: "period", NormalizedName: "period"})
+				Expect(err).NotTo(HaveOccurred())
+
+				count, err := pg2mysql.GetRangeBoundsLossRowCount(pg, srcTable, period, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(int64(2)))
+			})
+		})
+
+		Context("when strings don't fit the destination's character set or byte limit", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_charsets (id integer NOT NULL, name varchar(10), body text)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_charsets (`id` integer NOT NULL, `name` varchar(10) CHARACTER SET utf8, `body` tinytext CHARACTER SET utf8mb4)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_charsets (id, name, body) VALUES
+					(1, 'plain', repeat('é', 100)),
+					(2, 'smile 😀', NULL),
+					(3, NULL, repeat('é', 200)),
+					(4, NULL, '😀')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_charsets`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_charsets")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports supplementary characters for utf8 columns and strings over the byte limit", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_charsets",
+					IncompatibleRowIDs:   []int{2, 3},
+					IncompatibleRowCount: 2,
+				}))
+			})
+
+			It("reports which column fails the byte limit and which the character set", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(resultFor(result, "table_with_charsets").Findings).To(Equal([]pg2mysql.ColumnFinding{
+					{
+						ColumnName: "body",
+						Rule:       pg2mysql.RuleLength,
+						RowIDs:     []int{3},
+						RowCount:   1,
+						MaxSize:    400,
+						Limit:      255,
+						Samples:    []string{strings.Repeat("é", 40) + "..."},
+					},
+					{
+						ColumnName: "name",
+						Rule:       pg2mysql.RuleCharset,
+						RowIDs:     []int{2},
+						RowCount:   1,
+						Samples:    []string{"smile 😀"},
+					},
+				}))
+			})
+		})
+
+		Context("when NULLs are headed for NOT NULL columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_nulls (id integer NOT NULL, name text, score integer)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_nulls (`id` integer NOT NULL, `name` varchar(10) NOT NULL, `score` integer NOT NULL DEFAULT 0)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_nulls (id, name, score) VALUES
+					(1, 'a', 1),
+					(2, NULL, 1),
+					(3, 'b', NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_nulls`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_nulls")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the rows holding them", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_nulls",
+					IncompatibleRowIDs:   []int{2, 3},
+					IncompatibleRowCount: 2,
+				}))
+			})
+
+			It("accepts NULLs replaced by the destination default", func() {
+				conversions := &pg2mysql.Conversions{NullDefaults: true}
+				result, err := pg2mysql.NewValidator(pg, mysql, conversions, nil).Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_nulls",
+					IncompatibleRowIDs:   []int{2},
+					IncompatibleRowCount: 1,
+				}))
+			})
+		})
+
+		Context("when columns are missing from either side", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_missing_columns (id integer NOT NULL, only_in_pg text)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_missing_columns (`id` integer NOT NULL, `only_in_mysql` text)")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_missing_columns`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_missing_columns")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports schema problems without checking the rows", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+					TableName: "table_with_missing_columns",
+					SchemaProblems: []string{
+						"column only_in_mysql is missing from the source",
+						"column only_in_pg is missing from the destination",
+					},
+				}))
+			})
+		})
+
+		Context("when the config declares type rules", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_rules (id integer NOT NULL, code smallint, label varchar(20))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_rules (`id` integer NOT NULL, `code` smallint, `label` varchar(20))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_rules (id, code, label) VALUES
+					(1, 5, 'a'),
+					(2, -3, 'b'),
+					(3, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_rules`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_rules")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("accepts columns of the same type and length", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(ContainElement(pg2mysql.ValidationResult{
+					TableName: "table_with_rules",
+				}))
+			})
+
+			It("checks rows with the SQL of a rule", func() {
+				conversions := &pg2mysql.Conversions{TypeRuleConfigs: []pg2mysql.TypeRuleConfig{
+					{Source: "smallint", Destination: "smallint", Verdict: pg2mysql.VerdictLossy, Check: "{column} < 0"},
+				}}
+				result, err := pg2mysql.NewValidator(pg, mysql, conversions, nil).Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resultFor(result, "table_with_rules").IncompatibleRowIDs).To(Equal([]int{2}))
+				Expect(resultFor(result, "table_with_rules").Findings).To(Equal([]pg2mysql.ColumnFinding{
+					{
+						ColumnName: "code",
+						Rule:       pg2mysql.RuleType,
+						RowIDs:     []int{2},
+						RowCount:   1,
+						Samples:    []string{"-3"},
+					},
+				}))
+			})
+
+			It("lets rules naming the destination type only override built-in rules", func() {
+				conversions := &pg2mysql.Conversions{TypeRuleConfigs: []pg2mysql.TypeRuleConfig{
+					{Source: "*", Destination: "smallint", Verdict: pg2mysql.VerdictLossy, Check: "{column} < 0"},
+				}}
+				result, err := pg2mysql.NewValidator(pg, mysql, conversions, nil).Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resultFor(result, "table_with_rules").IncompatibleRowIDs).To(Equal([]int{2}))
+			})
+
+			It("reports every value of an incompatible pair of types", func() {
+				conversions := &pg2mysql.Conversions{TypeRuleConfigs: []pg2mysql.TypeRuleConfig{
+					{Source: "character varying", Destination: "*", Verdict: pg2mysql.VerdictIncompatible},
+				}}
+				result, err := pg2mysql.NewValidator(pg, mysql, conversions, nil).Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resultFor(result, "table_with_rules").IncompatibleRowIDs).To(Equal([]int{1, 2}))
+				Expect(resultFor(result, "table_with_rules").SchemaProblems).To(Equal([]string{
+					"column label cannot be converted from character varying to varchar: declared in the config",
+				}))
+			})
+
+			It("rejects rules with an unknown verdict", func() {
+				conversions := &pg2mysql.Conversions{TypeRuleConfigs: []pg2mysql.TypeRuleConfig{
+					{Source: "smallint", Destination: "smallint", Verdict: "maybe"},
+				}}
+				_, err := pg2mysql.NewValidator(pg, mysql, conversions, nil).Validate()
+				Expect(err).To(MatchError(ContainSubstring(`unknown verdict "maybe"`)))
+			})
+		})
+
+		Context("when integers don't fit the destination's range", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_integers (id integer NOT NULL, big bigint, small smallint, quantity integer)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_integers (`id` integer NOT NULL, `big` int, `small` tinyint, `quantity` int unsigned)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_integers (id, big, small, quantity) VALUES
+					(1, 1, 1, 1),
+					(2, 3000000000, NULL, NULL),
+					(3, NULL, 200, NULL),
+					(4, NULL, NULL, -1),
+					(5, -2147483648, -128, 2147483647)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_integers`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_integers")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the rows out of range, including negatives for unsigned columns", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_integers",
+					IncompatibleRowIDs:   []int{2, 3, 4},
+					IncompatibleRowCount: 3,
+				}))
+			})
+		})
+
+		Context("when dates and timestamps are outside the destination's range", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_dates (id integer NOT NULL, born_on date, created_at timestamp, seen_at timestamp with time zone)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_dates (`id` integer NOT NULL, `born_on` date, `created_at` datetime, `seen_at` timestamp NULL)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_dates (id, born_on, created_at, seen_at) VALUES
+					(1, '2000-01-01', '2000-01-01 10:00:00', '2000-01-01 10:00:00+00'),
+					(2, '0999-12-31', NULL, NULL),
+					(3, NULL, 'infinity', NULL),
+					(4, NULL, NULL, '2038-01-20 00:00:00+00'),
+					(5, NULL, NULL, '1970-01-01 00:00:00+00')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_dates`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_dates")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the rows out of range", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName:            "table_with_dates",
+					IncompatibleRowIDs:   []int{2, 3, 4, 5},
+					IncompatibleRowCount: 4,
+				}))
+			})
+
+			It("accepts the columns with a clamp or null policy", func() {
+				conversions := &pg2mysql.Conversions{
+					OutOfRange: map[string]map[string]string{
+						"table_with_dates": {"born_on": "clamp", "created_at": "null", "seen_at": "clamp"},
+					},
+				}
+				result, err := pg2mysql.NewValidator(pg, mysql, conversions, nil).Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName: "table_with_dates",
+				}))
+			})
+		})
+
+		Context("when keys distinct in postgres collide under the destination's collation", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_unique_names (id integer NOT NULL, name text, code citext)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_unique_names (`id` integer NOT NULL, " +
+					"`name` varchar(20) CHARACTER SET utf8 COLLATE utf8_general_ci, `code` varchar(20) CHARACTER SET utf8 COLLATE utf8_bin, " +
+					"UNIQUE KEY name_key (`name`), UNIQUE KEY code_key (`code`))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_unique_names (id, name, code) VALUES
+					(1, 'Foo', 'a'),
+					(2, 'foo', 'b '),
+					(3, 'bar', 'b'),
+					(4, 'bar ', NULL),
+					(5, NULL, NULL),
+					(6, NULL, 'A'),
+					(7, 'café', NULL),
+					(8, 'Cafe', NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_unique_names`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_unique_names")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports each colliding key group with its ids", func() {
+				result, err := validator.Validate()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(withoutFindings(result)).To(ContainElement(pg2mysql.ValidationResult{
+					TableName: "table_with_unique_names",
+					UniqueCollisions: []pg2mysql.UniqueCollision{
+						{IndexName: "code_key", Key: []string{"b"}, RowIDs: []int{2, 3}, RowCount: 2},
+						{IndexName: "name_key", Key: []string{"bar"}, RowIDs: []int{3, 4}, RowCount: 2},
+						{IndexName: "name_key", Key: []string{"cafe"}, RowIDs: []int{7, 8}, RowCount: 2},
+						{IndexName: "name_key", Key: []string{"foo"}, RowIDs: []int{1, 2}, RowCount: 2},
+					},
+				}))
+			})
+		})
 	})
 })
+
+// withoutFindings strips the column findings from results, for assertions
+// about the incompatible rows alone.
+func withoutFindings(results []pg2mysql.ValidationResult) []pg2mysql.ValidationResult {
+	stripped := make([]pg2mysql.ValidationResult, len(results))
+	for i, result := range results {
+		result.Findings = nil
+		stripped[i] = result
+	}
+	return stripped
+}
+
+func resultFor(results []pg2mysql.ValidationResult, tableName string) pg2mysql.ValidationResult {
+	for _, result := range results {
+		if result.TableName == tableName {
+			return result
+		}
+	}
+	Fail("no result for " + tableName)
+	return pg2mysql.ValidationResult{}
+}
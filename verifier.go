@@ -12,14 +12,21 @@ type Verifier interface {
 
 type verifier struct {
 	src, dst DB
+	conversions *Conversions
+	method   string
     debug map[string]bool
 	watcher  VerifierWatcher
 }
 
-func NewVerifier(src, dst DB, debug map[string]bool, watcher VerifierWatcher) Verifier {
+// NewVerifier returns a verifier comparing tables with method, one of
+// VerifyMethodRows, VerifyMethodChecksum and VerifyMethodDiff. An empty
+// method compares rows.
+func NewVerifier(src, dst DB, conversions *Conversions, method string, debug map[string]bool, watcher VerifierWatcher) Verifier {
 	return &verifier{
 		src:     src,
 		dst:     dst,
+		conversions: conversions,
+		method:  method,
         debug:   debug,
 		watcher: watcher,
 	}
@@ -31,41 +38,200 @@ func (v *verifier) Verify() error {
 		return fmt.Errorf("failed to build source schema: %s", err)
 	}
 
+	if err = v.conversions.SplitRangeColumns(srcSchema); err != nil {
+		return fmt.Errorf("failed to split range columns: %s", err)
+	}
+
 	dstSchema, err := BuildSchema(v.dst)
 	if err != nil {
 		return fmt.Errorf("failed to build source schema: %s", err)
 	}
 
+	rules, err := v.conversions.TypeRules()
+	if err != nil {
+		return fmt.Errorf("failed loading type rules: %s", err)
+	}
+
     for _, tableName := range  MakeSliceOrderedTableNames(srcSchema.Tables) {
         srcTable := srcSchema.Tables[tableName]
 		v.watcher.TableVerificationDidStart(srcTable.ActualName)
 
 		dstTable, err := dstSchema.GetTable(srcTable.NormalizedName)
 		if err != nil {
-            return fmt.Errorf("failed to get table from destination schema: %s", err)
-        }
-
-		var missingRows int64
-		var missingIDs []string
-		err = EachMissingRow(v.src, v.dst, srcTable, dstTable, v.debug, func(scanArgs []interface{}) {
-			if colIndex, _, getColErr := srcTable.GetColumn(&IDColumn); getColErr == nil {
-				if colID, ok := scanArgs[colIndex].(*interface{}); ok {
-					missingIDs = append(missingIDs, ColIDToString(*colID))
-				}
-			}
-			missingRows++
-		})
-		if err != nil {
-			v.watcher.TableVerificationDidFinishWithError(srcTable.ActualName, err)
+			v.watcher.TableVerificationDidFindSchemaProblems(srcTable.ActualName, []string{"table is missing from the destination"})
 			continue
 		}
 
-		v.watcher.TableVerificationDidFinish(srcTable.ActualName, missingRows, missingIDs)
+		problems, checkable := GetSchemaProblems(rules, srcTable, dstTable)
+		// rows are compared column by column, so both sides need the same columns
+		if checkable && len(srcTable.Columns) == len(dstTable.Columns) {
+			v.verifyTable(srcTable, dstTable)
+		}
+		if len(problems) > 0 {
+			v.watcher.TableVerificationDidFindSchemaProblems(srcTable.ActualName, problems)
+		}
+	}
+
+	for _, tableName := range MakeSliceOrderedTableNames(dstSchema.Tables) {
+		dstTable := dstSchema.Tables[tableName]
+		if _, err := srcSchema.GetTable(dstTable.NormalizedName); err == nil {
+			continue
+		}
+		v.watcher.TableVerificationDidStart(dstTable.ActualName)
+		v.watcher.TableVerificationDidFindSchemaProblems(dstTable.ActualName, []string{"table is missing from the source"})
 	}
 
 	return nil
 }
 
+// verifyTable compares the rows of srcTable and dstTable with the method
+// of v, telling the watcher of the rows that differ.
+func (v *verifier) verifyTable(srcTable, dstTable *Table) {
+	if v.method == VerifyMethodChecksum {
+		match, ok, err := CompareTableChecksums(v.src, v.dst, v.conversions, srcTable, dstTable, v.debug)
+		if err != nil {
+			v.watcher.TableVerificationDidFinishWithError(srcTable.ActualName, err)
+			return
+		}
+
+		if ok && match {
+			v.watcher.TableVerificationDidFinish(srcTable.ActualName, 0, nil, 0, nil)
+			return
+		}
+
+		// tables whose checksums differ are narrowed down by key ranges
+		if ok {
+			differences, chunked, err := FindDivergentKeys(v.src, v.dst, v.conversions, srcTable, dstTable, v.debug)
+			if err != nil {
+				v.watcher.TableVerificationDidFinishWithError(srcTable.ActualName, err)
+				return
+			}
+
+			if chunked {
+				v.finishWithDifferences(srcTable.ActualName, differences)
+				return
+			}
+		}
+
+		// tables without a primary key or id, or whose checksums can't be computed alike, have their rows compared
+	}
+
+	// the diff method joins the rows of tables with a key by key
+	if v.method == VerifyMethodDiff && v.verifyColumns(srcTable, dstTable) {
+		return
+	}
+
+	// tables with a primary key or an id are read in order on both sides and
+	// merged, the others have each row looked up in the destination
+	differences, merged, err := MergeTableRows(v.src, v.dst, v.conversions, srcTable, dstTable, v.debug)
+	if err != nil {
+		v.watcher.TableVerificationDidFinishWithError(srcTable.ActualName, err)
+		return
+	}
+	if merged {
+		v.finishWithDifferences(srcTable.ActualName, differences)
+		return
+	}
+
+	var missingRows int64
+	var missingIDs []string
+	// rows with values out of range are not migrated, and so missing
+	err = EachMissingRow(v.src, v.dst, v.conversions, srcTable, dstTable, v.debug, func(scanArgs []interface{}, _ error) {
+		if colIndex, _, getColErr := srcTable.GetColumn(&IDColumn); getColErr == nil {
+			if colID, ok := scanArgs[colIndex].(*interface{}); ok {
+				missingIDs = append(missingIDs, ColIDToString(*colID))
+			}
+		}
+		missingRows++
+	})
+	if err != nil {
+		v.watcher.TableVerificationDidFinishWithError(srcTable.ActualName, err)
+		return
+	}
+
+	extraRows, extraIDs, err := v.extraRows(srcTable, dstTable, missingRows)
+	if err != nil {
+		v.watcher.TableVerificationDidFinishWithError(srcTable.ActualName, err)
+		return
+	}
+
+	v.watcher.TableVerificationDidFinish(srcTable.ActualName, missingRows, missingIDs, extraRows, extraIDs)
+}
+
+// finishWithDifferences tells the watcher of the rows that differ in a
+// table whose rows were matched by key.
+func (v *verifier) finishWithDifferences(tableName string, differences KeyDifferences) {
+	// changed rows have no matching row in the destination, as with rows
+	missingIDs := differences.UnmatchedIDs()
+	v.watcher.TableVerificationDidFinish(tableName, int64(len(missingIDs)), missingIDs, int64(len(differences.ExtraIDs)), differences.ExtraIDs)
+	v.watcher.TableVerificationDidFindDifferences(tableName, differences.MissingIDs, differences.ExtraIDs, differences.ChangedIDs)
+}
+
+// verifyColumns verifies a table by joining its rows to the destination
+// rows with the same key, telling the watcher of the columns that differ.
+// It returns false, having told the watcher nothing, when the tables have
+// no key to join rows by.
+func (v *verifier) verifyColumns(srcTable, dstTable *Table) bool {
+	var unmatchedIDs, missingIDs, changedIDs []string
+	ok, err := EachRowDiff(v.src, v.dst, v.conversions, srcTable, dstTable, v.debug, func(id string, missing bool, diffs []ColumnDiff) {
+		unmatchedIDs = append(unmatchedIDs, id)
+		if missing {
+			missingIDs = append(missingIDs, id)
+			return
+		}
+		changedIDs = append(changedIDs, id)
+		v.watcher.TableVerificationDidFindColumnDiffs(srcTable.ActualName, diffs)
+	})
+	if err != nil {
+		v.watcher.TableVerificationDidFinishWithError(srcTable.ActualName, err)
+		return true
+	}
+	if !ok {
+		return false
+	}
+
+	extraRows, extraIDs, err := v.extraRows(srcTable, dstTable, int64(len(unmatchedIDs)))
+	if err != nil {
+		v.watcher.TableVerificationDidFinishWithError(srcTable.ActualName, err)
+		return true
+	}
+
+	// changed rows have no matching row in the destination, as with rows
+	v.watcher.TableVerificationDidFinish(srcTable.ActualName, int64(len(unmatchedIDs)), unmatchedIDs, extraRows, extraIDs)
+	v.watcher.TableVerificationDidFindDifferences(srcTable.ActualName, missingIDs, extraIDs, changedIDs)
+	return true
+}
+
+// extraRows returns the rows of dstTable with no matching row in srcTable.
+// They are matched by id when both tables have one. Otherwise they are
+// counted as the destination rows left once each source row found in the
+// destination, all but missingRows, is matched to one of them.
+func (v *verifier) extraRows(srcTable, dstTable *Table, missingRows int64) (int64, []string, error) {
+	var extraRows int64
+	var extraIDs []string
+	ok, err := EachExtraRow(v.src, v.dst, srcTable, dstTable, v.debug, func(id string) {
+		extraIDs = append(extraIDs, id)
+		extraRows++
+	})
+	if err != nil || ok {
+		return extraRows, extraIDs, err
+	}
+
+	srcRows, err := CountRows(v.src, srcTable, v.debug)
+	if err != nil {
+		return 0, nil, err
+	}
+	dstRows, err := CountRows(v.dst, dstTable, v.debug)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if extraRows = dstRows - (srcRows - missingRows); extraRows < 0 {
+		extraRows = 0
+	}
+	return extraRows, nil, nil
+}
+
 func ColIDToString(colID interface{}) string {
 	switch v := colID.(type) {
 	case []byte:
@@ -1,7 +1,9 @@
 package pg2mysql_test
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,6 +29,8 @@ var _ = Describe("Verifier", func() {
 			"127.0.0.1",
 			3306,
 			true,
+			nil,
+			"",
 		)
 
 		err := mysql.Open()
@@ -44,7 +48,7 @@ var _ = Describe("Verifier", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		watcher = &pg2mysqlfakes.FakeVerifierWatcher{}
-		verifier = pg2mysql.NewVerifier(pg, mysql, watcher)
+		verifier = pg2mysql.NewVerifier(pg, mysql, &pg2mysql.Conversions{}, "", nil, watcher)
 	})
 
 	AfterEach(func() {
@@ -60,12 +64,44 @@ var _ = Describe("Verifier", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(watcher.TableVerificationDidFinishCallCount()).To(Equal(3))
 			for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
-				_, missingRows, missingIDs := watcher.TableVerificationDidFinishArgsForCall(i)
+				_, missingRows, missingIDs, extraRows, extraIDs := watcher.TableVerificationDidFinishArgsForCall(i)
 				Expect(missingRows).To(BeZero())
 				Expect(missingIDs).To(BeNil())
+				Expect(extraRows).To(BeZero())
+				Expect(extraIDs).To(BeNil())
 			}
 		})
 
+		Context("when there is data in mysql that is not in postgres", func() {
+			BeforeEach(func() {
+				_, err := mysqlRunner.DB().Exec("INSERT INTO table_with_id (id, name, ci_name, created_at, truthiness) VALUES (4, 'some-name', 'some-ci-name', now(), false)")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_string_id (id, name) VALUES ('some-string-id', 'some-name')")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_without_id (name, ci_name, created_at, truthiness) VALUES ('some-name', 'some-ci-name', now(), false)")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("notifies the watcher of the extra rows", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishCallCount()).To(Equal(3))
+
+				expected := map[string][]string{
+					"table_with_id":        {"4"},
+					"table_with_string_id": {"some-string-id"},
+					"table_without_id":     nil,
+				}
+
+				for i := 0; i < len(expected); i++ {
+					tableName, missingRows, _, extraRows, extraIDs := watcher.TableVerificationDidFinishArgsForCall(i)
+					Expect(missingRows).To(BeZero())
+					Expect(extraRows).To(Equal(int64(1)), fmt.Sprintf("unexpected result for %s", tableName))
+					Expect(extraIDs).To(Equal(expected[tableName]), fmt.Sprintf("unexpected result for %s", tableName))
+				}
+			})
+		})
+
 		Context("when there is data in postgres that is not in mysql", func() {
 			var lastInsertID int
 			BeforeEach(func() {
@@ -85,7 +121,7 @@ var _ = Describe("Verifier", func() {
 				}
 
 				for i := 0; i < len(expected); i++ {
-					tableName, missingRows, missingIDs := watcher.TableVerificationDidFinishArgsForCall(i)
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
 					Expect(missingRows).To(Equal(expected[tableName]), fmt.Sprintf("unexpected result for %s", tableName))
 					if tableName == "table_with_id" {
 						Expect(missingIDs).To(Equal([]string{fmt.Sprintf("%d", lastInsertID)}))
@@ -131,12 +167,454 @@ var _ = Describe("Verifier", func() {
 				}
 
 				for i := 0; i < len(expected); i++ {
-					tableName, missingRows, missingIDs := watcher.TableVerificationDidFinishArgsForCall(i)
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
 					Expect(missingRows).To(Equal(expected[tableName]), fmt.Sprintf("unexpected result for %s", tableName))
 					Expect(missingIDs).To(BeNil())
 				}
 			})
 		})
+		Context("when verifying with checksums", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_checksums (id integer NOT NULL, name varchar(20), amount numeric(10,2), flag boolean, created_at timestamp, day date, token uuid)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_checksums (`id` integer NOT NULL, `name` varchar(20), `amount` decimal(10,2), `flag` tinyint(1), `created_at` datetime, `day` date, `token` binary(16))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_checksums (id, name, amount, flag, created_at, day, token) VALUES
+					(1, 'some-name', 1.5, true, '2020-01-02 03:04:05.6', '2020-01-02', 'c1a7b2e0-5a4b-4c8e-9a38-1d2f3e4a5b6c'),
+					(2, NULL, NULL, false, NULL, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_checksums (`id`, `name`, `amount`, `flag`, `created_at`, `day`, `token`) VALUES " +
+					"(1, 'some-name', 1.5, 1, '2020-01-02 03:04:06', '2020-01-02', UNHEX('c1a7b2e05a4b4c8e9a381d2f3e4a5b6c'))," +
+					"(2, NULL, NULL, 0, NULL, NULL, NULL)")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_checksums`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_checksums")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("computes the same checksum on both sides", func() {
+				srcSchema, err := pg2mysql.BuildSchema(pg)
+				Expect(err).NotTo(HaveOccurred())
+				dstSchema, err := pg2mysql.BuildSchema(mysql)
+				Expect(err).NotTo(HaveOccurred())
+
+				srcTable, err := srcSchema.GetTable("table_with_checksums")
+				Expect(err).NotTo(HaveOccurred())
+				dstTable, err := dstSchema.GetTable("table_with_checksums")
+				Expect(err).NotTo(HaveOccurred())
+
+				match, ok, err := pg2mysql.CompareTableChecksums(pg, mysql, &pg2mysql.Conversions{}, srcTable, dstTable, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				Expect(match).To(BeTrue())
+			})
+
+			It("confirms matching tables", func() {
+				err := pg2mysql.NewVerifier(pg, mysql, &pg2mysql.Conversions{}, pg2mysql.VerifyMethodChecksum, nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishCallCount()).To(Equal(4))
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					_, missingRows, _, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					Expect(missingRows).To(BeZero())
+				}
+			})
+
+			It("compares the rows of tables whose checksums differ", func() {
+				_, err := mysqlRunner.DB().Exec("DELETE FROM table_with_checksums WHERE `id` = 2")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = pg2mysql.NewVerifier(pg, mysql, &pg2mysql.Conversions{}, pg2mysql.VerifyMethodChecksum, nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					if tableName == "table_with_checksums" {
+						found = true
+						Expect(missingRows).To(Equal(int64(1)))
+						Expect(missingIDs).To(Equal([]string{"2"}))
+					}
+				}
+				Expect(found).To(BeTrue())
+			})
+
+			It("merges the rows of keyed tables when comparing rows", func() {
+				_, err := mysqlRunner.DB().Exec("DELETE FROM table_with_checksums WHERE `id` = 2")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("UPDATE table_with_checksums SET `name` = 'other-name' WHERE `id` = 1")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_checksums (`id`, `name`) VALUES (10, 'extra-name')")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					tableName, missingRows, missingIDs, extraRows, extraIDs := watcher.TableVerificationDidFinishArgsForCall(i)
+					if tableName == "table_with_checksums" {
+						Expect(missingRows).To(Equal(int64(2)))
+						Expect(missingIDs).To(Equal([]string{"1", "2"}))
+						Expect(extraRows).To(Equal(int64(1)))
+						Expect(extraIDs).To(Equal([]string{"10"}))
+					}
+				}
+
+				var found bool
+				for i := 0; i < watcher.TableVerificationDidFindDifferencesCallCount(); i++ {
+					tableName, missingIDs, _, changedIDs := watcher.TableVerificationDidFindDifferencesArgsForCall(i)
+					if tableName == "table_with_checksums" {
+						found = true
+						Expect(missingIDs).To(Equal([]string{"2"}))
+						Expect(changedIDs).To(Equal([]string{"1"}))
+					}
+				}
+				Expect(found).To(BeTrue())
+			})
+
+			Context("when the tables differ across many key ranges", func() {
+				BeforeEach(func() {
+					_, err := pgRunner.DB().Exec(`INSERT INTO table_with_checksums (id, name) SELECT n, 'name-' || n FROM generate_series(3, 500) AS n`)
+					Expect(err).NotTo(HaveOccurred())
+					values := make([]string, 0, 498)
+					for n := 3; n <= 500; n++ {
+						values = append(values, fmt.Sprintf("(%d, 'name-%d')", n, n))
+					}
+					_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_checksums (`id`, `name`) VALUES " + strings.Join(values, ","))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = mysqlRunner.DB().Exec("DELETE FROM table_with_checksums WHERE `id` IN (7, 321)")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = mysqlRunner.DB().Exec("UPDATE table_with_checksums SET `name` = 'other-name' WHERE `id` = 250")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_checksums (`id`, `name`) VALUES (1000, 'extra-name')")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("finds the missing, extra and changed keys", func() {
+					srcSchema, err := pg2mysql.BuildSchema(pg)
+					Expect(err).NotTo(HaveOccurred())
+					dstSchema, err := pg2mysql.BuildSchema(mysql)
+					Expect(err).NotTo(HaveOccurred())
+
+					srcTable, err := srcSchema.GetTable("table_with_checksums")
+					Expect(err).NotTo(HaveOccurred())
+					dstTable, err := dstSchema.GetTable("table_with_checksums")
+					Expect(err).NotTo(HaveOccurred())
+
+					differences, ok, err := pg2mysql.FindDivergentKeys(pg, mysql, &pg2mysql.Conversions{}, srcTable, dstTable, nil)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ok).To(BeTrue())
+					Expect(differences.MissingIDs).To(Equal([]string{"7", "321"}))
+					Expect(differences.ExtraIDs).To(Equal([]string{"1000"}))
+					Expect(differences.ChangedIDs).To(Equal([]string{"250"}))
+				})
+
+				It("notifies the watcher of the differing keys", func() {
+					err := pg2mysql.NewVerifier(pg, mysql, &pg2mysql.Conversions{}, pg2mysql.VerifyMethodChecksum, nil, watcher).Verify()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+					for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+						tableName, missingRows, missingIDs, extraRows, extraIDs := watcher.TableVerificationDidFinishArgsForCall(i)
+						if tableName == "table_with_checksums" {
+							Expect(missingRows).To(Equal(int64(3)))
+							Expect(missingIDs).To(Equal([]string{"7", "250", "321"}))
+							Expect(extraRows).To(Equal(int64(1)))
+							Expect(extraIDs).To(Equal([]string{"1000"}))
+						}
+					}
+
+					Expect(watcher.TableVerificationDidFindDifferencesCallCount()).To(Equal(1))
+					tableName, missingIDs, extraIDs, changedIDs := watcher.TableVerificationDidFindDifferencesArgsForCall(0)
+					Expect(tableName).To(Equal("table_with_checksums"))
+					Expect(missingIDs).To(Equal([]string{"7", "321"}))
+					Expect(extraIDs).To(Equal([]string{"1000"}))
+					Expect(changedIDs).To(Equal([]string{"250"}))
+				})
+			})
+		})
+
+		Context("when the tables are keyed by a composite primary key", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_composite_key (tenant text NOT NULL, n integer NOT NULL, name text, PRIMARY KEY (tenant, n))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_composite_key (`tenant` varchar(20) NOT NULL, `n` integer NOT NULL, `name` varchar(20), PRIMARY KEY (`tenant`, `n`))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_composite_key (tenant, n, name) SELECT 'tenant-' || (n % 3), n, 'name-' || n FROM generate_series(1, 300) AS n`)
+				Expect(err).NotTo(HaveOccurred())
+				values := make([]string, 0, 300)
+				for n := 1; n <= 300; n++ {
+					values = append(values, fmt.Sprintf("('tenant-%d', %d, 'name-%d')", n%3, n, n))
+				}
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_composite_key (`tenant`, `n`, `name`) VALUES " + strings.Join(values, ","))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = mysqlRunner.DB().Exec("DELETE FROM table_with_composite_key WHERE `tenant` = 'tenant-1' AND `n` = 7")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("UPDATE table_with_composite_key SET `name` = 'other-name' WHERE `tenant` = 'tenant-0' AND `n` = 150")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_composite_key (`tenant`, `n`, `name`) VALUES ('tenant-9', 1, 'extra-name')")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_composite_key`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_composite_key")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("finds the missing, extra and changed keys by binary key ranges", func() {
+				srcSchema, err := pg2mysql.BuildSchema(pg)
+				Expect(err).NotTo(HaveOccurred())
+				dstSchema, err := pg2mysql.BuildSchema(mysql)
+				Expect(err).NotTo(HaveOccurred())
+
+				srcTable, err := srcSchema.GetTable("table_with_composite_key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(srcTable.PrimaryKey).To(Equal([]string{"tenant", "n"}))
+				dstTable, err := dstSchema.GetTable("table_with_composite_key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(dstTable.PrimaryKey).To(Equal([]string{"tenant", "n"}))
+
+				differences, ok, err := pg2mysql.FindDivergentKeys(pg, mysql, &pg2mysql.Conversions{}, srcTable, dstTable, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				Expect(differences.MissingIDs).To(Equal([]string{"tenant-1,7"}))
+				Expect(differences.ExtraIDs).To(Equal([]string{"tenant-9,1"}))
+				Expect(differences.ChangedIDs).To(Equal([]string{"tenant-0,150"}))
+			})
+
+			It("joins the rows on the primary key to diff their columns", func() {
+				srcSchema, err := pg2mysql.BuildSchema(pg)
+				Expect(err).NotTo(HaveOccurred())
+				dstSchema, err := pg2mysql.BuildSchema(mysql)
+				Expect(err).NotTo(HaveOccurred())
+
+				srcTable, err := srcSchema.GetTable("table_with_composite_key")
+				Expect(err).NotTo(HaveOccurred())
+				dstTable, err := dstSchema.GetTable("table_with_composite_key")
+				Expect(err).NotTo(HaveOccurred())
+
+				var missingIDs []string
+				var diffs []pg2mysql.ColumnDiff
+				ok, err := pg2mysql.EachRowDiff(pg, mysql, &pg2mysql.Conversions{}, srcTable, dstTable, nil, func(id string, missing bool, rowDiffs []pg2mysql.ColumnDiff) {
+					if missing {
+						missingIDs = append(missingIDs, id)
+					}
+					diffs = append(diffs, rowDiffs...)
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				Expect(missingIDs).To(Equal([]string{"tenant-1,7"}))
+				Expect(diffs).To(Equal([]pg2mysql.ColumnDiff{
+					{ID: "tenant-0,150", ColumnName: "name", Source: "name-150", Destination: "other-name"},
+				}))
+			})
+		})
+
+		Context("when some ids are NULL", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_null_ids (id integer, name text)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_null_ids (`id` integer, `name` varchar(20))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_null_ids (id, name) VALUES (NULL, 'no-id'), (1, 'some-name'), (2, 'other-name')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_null_ids (`id`, `name`) VALUES (NULL, 'no-id'), (1, 'some-name')")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_null_ids`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_null_ids")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("merges the rows in the same order on both sides", func() {
+				srcSchema, err := pg2mysql.BuildSchema(pg)
+				Expect(err).NotTo(HaveOccurred())
+				dstSchema, err := pg2mysql.BuildSchema(mysql)
+				Expect(err).NotTo(HaveOccurred())
+
+				srcTable, err := srcSchema.GetTable("table_with_null_ids")
+				Expect(err).NotTo(HaveOccurred())
+				dstTable, err := dstSchema.GetTable("table_with_null_ids")
+				Expect(err).NotTo(HaveOccurred())
+
+				differences, ok, err := pg2mysql.MergeTableRows(pg, mysql, &pg2mysql.Conversions{}, srcTable, dstTable, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				Expect(differences.MissingIDs).To(Equal([]string{"2"}))
+				Expect(differences.ExtraIDs).To(BeNil())
+				Expect(differences.ChangedIDs).To(BeNil())
+			})
+		})
+
+		Context("when string ids sort differently in each database", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_collated_ids (id varchar(10) NOT NULL, name varchar(20))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_collated_ids (`id` varchar(10) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL, `name` varchar(20))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_collated_ids (id, name) VALUES ('a', 'some-name'), ('B', 'some-name'), ('c', 'some-name'), ('é', 'some-name'), ('_', 'some-name')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_collated_ids (`id`, `name`) VALUES ('a', 'some-name'), ('B', 'some-name'), ('é', 'some-name'), ('_', 'some-name'), ('Z', 'some-name')")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_collated_ids`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_collated_ids")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("merges the rows in the byte order of their ids", func() {
+				srcSchema, err := pg2mysql.BuildSchema(pg)
+				Expect(err).NotTo(HaveOccurred())
+				dstSchema, err := pg2mysql.BuildSchema(mysql)
+				Expect(err).NotTo(HaveOccurred())
+
+				srcTable, err := srcSchema.GetTable("table_with_collated_ids")
+				Expect(err).NotTo(HaveOccurred())
+				dstTable, err := dstSchema.GetTable("table_with_collated_ids")
+				Expect(err).NotTo(HaveOccurred())
+
+				differences, ok, err := pg2mysql.MergeTableRows(pg, mysql, &pg2mysql.Conversions{}, srcTable, dstTable, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				Expect(differences.MissingIDs).To(Equal([]string{"c"}))
+				Expect(differences.ExtraIDs).To(Equal([]string{"Z"}))
+				Expect(differences.ChangedIDs).To(BeEmpty())
+			})
+		})
+
+		Context("when verifying column by column", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_diffs (id integer NOT NULL, name varchar(20), label varchar(20), amount numeric(10,3), created_at timestamp(0))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_diffs (`id` integer NOT NULL, `name` varchar(20), `label` varchar(20), `amount` decimal(10,2), `created_at` datetime)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_diffs (id, name, label, amount, created_at) VALUES
+					(1, 'some-name', 'some-label', 1.5, '2020-01-02 03:04:05'),
+					(2, 'some-name', 'some-label', 1.005, '2020-01-02 03:04:05'),
+					(3, 'some-name', 'some-label', 1.5, '2020-01-02 03:04:05'),
+					(4, 'some-name', 'some-label', 1.5, '2020-01-02 03:04:05')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_diffs (`id`, `name`, `label`, `amount`, `created_at`) VALUES " +
+					"(1, 'some-name', 'some-label', 1.5, '2020-01-02 03:04:05')," +
+					"(2, 'some-name', 'some-lab', 1.01, '2020-01-02 03:04:05')," +
+					"(3, 'other-name', 'some-label', 1.5, '2020-01-02 05:04:05')")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_diffs`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_diffs")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("notifies the watcher of the columns that differ", func() {
+				err := pg2mysql.NewVerifier(pg, mysql, &pg2mysql.Conversions{}, pg2mysql.VerifyMethodDiff, nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				var diffs []pg2mysql.ColumnDiff
+				for i := 0; i < watcher.TableVerificationDidFindColumnDiffsCallCount(); i++ {
+					tableName, rowDiffs := watcher.TableVerificationDidFindColumnDiffsArgsForCall(i)
+					Expect(tableName).To(Equal("table_with_diffs"))
+					diffs = append(diffs, rowDiffs...)
+				}
+				Expect(diffs).To(Equal([]pg2mysql.ColumnDiff{
+					{ID: "2", ColumnName: "label", Source: "some-label", Destination: "some-lab", Explanation: pg2mysql.ExplanationTruncation},
+					{ID: "2", ColumnName: "amount", Source: "1.005", Destination: "1.01", Explanation: pg2mysql.ExplanationPrecision},
+					{ID: "3", ColumnName: "name", Source: "some-name", Destination: "other-name"},
+					{ID: "3", ColumnName: "created_at", Source: "2020-01-02 03:04:05 +00:00", Destination: "2020-01-02 05:04:05 +00:00"},
+				}))
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					if tableName == "table_with_diffs" {
+						Expect(missingRows).To(Equal(int64(3)))
+						Expect(missingIDs).To(Equal([]string{"2", "3", "4"}))
+					}
+				}
+
+				var found bool
+				for i := 0; i < watcher.TableVerificationDidFindDifferencesCallCount(); i++ {
+					tableName, missingIDs, extraIDs, changedIDs := watcher.TableVerificationDidFindDifferencesArgsForCall(i)
+					if tableName == "table_with_diffs" {
+						found = true
+						Expect(missingIDs).To(Equal([]string{"4"}))
+						Expect(extraIDs).To(BeNil())
+						Expect(changedIDs).To(Equal([]string{"2", "3"}))
+					}
+				}
+				Expect(found).To(BeTrue())
+			})
+
+			It("writes the columns that differ to a diff file", func() {
+				var out bytes.Buffer
+				diffWriter := pg2mysql.NewDiffWriter(&out)
+				diffWriter.Watcher = watcher
+
+				err := pg2mysql.NewVerifier(pg, mysql, &pg2mysql.Conversions{}, pg2mysql.VerifyMethodDiff, nil, diffWriter).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diffWriter.Err()).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFindColumnDiffsCallCount()).To(Equal(2))
+
+				lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+				Expect(lines).To(HaveLen(4))
+				Expect(lines[2]).To(MatchJSON(`{"table_name": "table_with_diffs", "id": "3", "column_name": "name", "source": "some-name", "destination": "other-name"}`))
+			})
+		})
+
+		Context("when mysql truncated the fractional seconds of a timestamp", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_truncated_time (id integer NOT NULL, created_at timestamp(3))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_truncated_time (`id` integer NOT NULL, `created_at` datetime)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_truncated_time (id, created_at) VALUES (1, '2020-01-02 03:04:05.678')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO table_with_truncated_time (`id`, `created_at`) VALUES (1, '2020-01-02 03:04:05')")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_truncated_time`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_truncated_time")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("explains the difference as a precision loss", func() {
+				err := pg2mysql.NewVerifier(pg, mysql, &pg2mysql.Conversions{}, pg2mysql.VerifyMethodDiff, nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFindColumnDiffsCallCount()).To(Equal(1))
+
+				tableName, diffs := watcher.TableVerificationDidFindColumnDiffsArgsForCall(0)
+				Expect(tableName).To(Equal("table_with_truncated_time"))
+				Expect(diffs).To(Equal([]pg2mysql.ColumnDiff{
+					{ID: "1", ColumnName: "created_at", Source: "2020-01-02 03:04:06 +00:00", Destination: "2020-01-02 03:04:05 +00:00", Explanation: pg2mysql.ExplanationPrecision},
+				}))
+			})
+		})
+
 		Context("when a timestamp that may get rounded by mysql", func() {
 			BeforeEach(func() {
 				msBump, _ := time.ParseDuration("700ms")
@@ -173,12 +651,522 @@ var _ = Describe("Verifier", func() {
 				}
 
 				for i := 0; i < len(expected); i++ {
-					tableName, missingRows, missingIDs := watcher.TableVerificationDidFinishArgsForCall(i)
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
 					Expect(missingRows).To(Equal(expected[tableName]), fmt.Sprintf("unexpected result for %s", tableName))
 					Expect(missingIDs).To(BeNil())
 				}
 			})
 		})
+
+		Context("when tables and columns are named with reserved words or mixed case", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE "order" (id integer NOT NULL, "group" text NOT NULL, "user" text, "MixedCase" text)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE `order` (`id` integer NOT NULL, `group` varchar(255) NOT NULL, `user` varchar(255), `MixedCase` varchar(255))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO "order" (id, "group", "user", "MixedCase") VALUES (1, 'some-group', 'some-user', 'Some-Value'), (2, 'other-group', NULL, 'Other-Value')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("INSERT INTO `order` (`id`, `group`, `user`, `MixedCase`) VALUES (1, 'some-group', 'some-user', 'Some-Value')")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE "order"`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE `order`")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("notifies the watcher", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+				Expect(watcher.TableVerificationDidFinishCallCount()).To(Equal(4))
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					if tableName == "order" {
+						Expect(missingRows).To(BeNumerically("==", 1))
+						Expect(missingIDs).To(Equal([]string{"2"}))
+					} else {
+						Expect(missingRows).To(BeZero())
+					}
+				}
+			})
+		})
+
+		Context("when json documents differ only in key order and whitespace", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_json (id integer NOT NULL, doc json, bdoc jsonb)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_json (`id` integer NOT NULL, `doc` json, `bdoc` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_json (id, doc, bdoc) VALUES (1, '{ "b" : 2,  "a" : [1, "x"] }', '{"b": 2, "a": [1, "x"]}'), (2, '{"a": 1}', '{"a": 1}')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_json (id, doc, bdoc) VALUES (1, '{"a":[1,"x"],"b":2}', '{"a":[1,"x"],"b":2}'), (2, '{"a": 2}', '{"a": 1}')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_json`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_json")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares the documents semantically", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					if tableName == "table_with_json" {
+						Expect(missingRows).To(BeNumerically("==", 1))
+						Expect(missingIDs).To(Equal([]string{"2"}))
+					}
+				}
+			})
+		})
+
+		Context("when there are array columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_array (id integer NOT NULL, tags text[], nums integer[])`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_array (`id` integer NOT NULL, `tags` varchar(20), `nums` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_array (id, tags, nums) VALUES (1, '{a,"b c"}', '{1,2}'), (2, '{x}', '{3}')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_array (id, tags, nums) VALUES (1, 'a,b c', '[1, 2]'), (2, 'x', '[4]')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_array`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_array")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares the converted arrays", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					if tableName == "table_with_array" {
+						Expect(missingRows).To(BeNumerically("==", 1))
+						Expect(missingIDs).To(Equal([]string{"2"}))
+					}
+				}
+			})
+		})
+
+		Context("when there are enum columns with renamed labels", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TYPE mood AS ENUM ('sad', 'ok', 'very happy'); CREATE TABLE table_with_enum (id integer NOT NULL, mood mood)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_enum (`id` integer NOT NULL, `mood` enum('sad','ok','happy'))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_enum (id, mood) VALUES (1, 'sad'), (2, 'very happy')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_enum (id, mood) VALUES (1, 'sad'), (2, 'happy')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_enum; DROP TYPE mood`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_enum")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares the renamed labels", func() {
+				conversions := &pg2mysql.Conversions{
+					EnumLabels: map[string]map[string]string{
+						"mood": {"very happy": "happy"},
+					},
+				}
+				err := pg2mysql.NewVerifier(pg, mysql, conversions, "", nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					_, missingRows, _, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					Expect(missingRows).To(BeZero())
+				}
+			})
+		})
+
+		Context("when a table exists on one side only", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_only_in_pg (id integer NOT NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_only_in_mysql (`id` integer NOT NULL)")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_only_in_pg`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_only_in_mysql")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the table as a schema problem and verifies the others", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishCallCount()).To(Equal(3))
+				Expect(watcher.TableVerificationDidFindSchemaProblemsCallCount()).To(Equal(2))
+
+				tableName, problems := watcher.TableVerificationDidFindSchemaProblemsArgsForCall(0)
+				Expect(tableName).To(Equal("table_only_in_pg"))
+				Expect(problems).To(Equal([]string{"table is missing from the destination"}))
+
+				tableName, problems = watcher.TableVerificationDidFindSchemaProblemsArgsForCall(1)
+				Expect(tableName).To(Equal("table_only_in_mysql"))
+				Expect(problems).To(Equal([]string{"table is missing from the source"}))
+			})
+		})
+
+		Context("when a table's columns differ", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_other_columns (id integer NOT NULL, name text, note text)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_other_columns (`id` integer NOT NULL, `name` text)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_other_columns (id, name, note) VALUES (1, 'a', 'b')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_other_columns`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_other_columns")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports a schema problem without comparing its rows", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+				Expect(watcher.TableVerificationDidFinishCallCount()).To(Equal(3))
+				Expect(watcher.TableVerificationDidFindSchemaProblemsCallCount()).To(Equal(1))
+
+				tableName, problems := watcher.TableVerificationDidFindSchemaProblemsArgsForCall(0)
+				Expect(tableName).To(Equal("table_with_other_columns"))
+				Expect(problems).To(Equal([]string{"column note is missing from the destination"}))
+			})
+		})
+
+		Context("when NULLs were written as the default of NOT NULL columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_nulls (id integer NOT NULL, score integer, updated_at timestamp)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_nulls (`id` integer NOT NULL, `score` integer NOT NULL DEFAULT 0, `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_nulls (id, score, updated_at) VALUES (1, NULL, NULL), (2, 5, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_nulls (id, score) VALUES (1, 0), (2, 0)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_nulls`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_nulls")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("accepts whatever default the destination holds", func() {
+				conversions := &pg2mysql.Conversions{NullDefaults: true}
+				err := pg2mysql.NewVerifier(pg, mysql, conversions, "", nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					if tableName == "table_with_nulls" {
+						Expect(missingRows).To(BeEquivalentTo(1))
+						Expect(missingIDs).To(Equal([]string{"2"}))
+					}
+				}
+			})
+		})
+
+		Context("when timestamps with time zone are verified in UTC", func() {
+			var utcMySQL pg2mysql.DB
+
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_timestamptz (id integer NOT NULL, happened_at timestamp with time zone, logged_at timestamp without time zone)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_timestamptz (`id` integer NOT NULL, `happened_at` datetime, `logged_at` datetime)")
+				Expect(err).NotTo(HaveOccurred())
+
+				// either side of the start of daylight saving time in Europe
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_timestamptz (id, happened_at, logged_at) VALUES
+					(1, '2021-03-28 01:30:00+01', '2021-03-28 01:30:00'),
+					(2, '2021-03-28 03:30:00+02', '2021-03-28 03:30:00')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_timestamptz (id, happened_at, logged_at) VALUES
+					(1, '2021-03-28 00:30:00', '2021-03-28 01:30:00'),
+					(2, '2021-03-28 03:30:00', '2021-03-28 03:30:00')`)
+				Expect(err).NotTo(HaveOccurred())
+
+				utcMySQL = pg2mysql.NewMySQLDB(
+					mysqlRunner.DBName,
+					"root",
+					"admin",
+					"127.0.0.1",
+					3306,
+					false,
+					time.UTC,
+					"",
+				)
+				err = utcMySQL.Open()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				err := utcMySQL.Close()
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`DROP TABLE table_with_timestamptz`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_timestamptz")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares the instants in UTC", func() {
+				conversions := &pg2mysql.Conversions{TimeZone: "UTC"}
+				err := pg2mysql.NewVerifier(pg, utcMySQL, conversions, "", nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					tableName, missingRows, missingIDs, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					if tableName == "table_with_timestamptz" {
+						Expect(missingRows).To(BeNumerically("==", 1))
+						Expect(missingIDs).To(Equal([]string{"2"}))
+					}
+				}
+			})
+
+			It("explains instants shifted by the offset of the configured zone", func() {
+				conversions := &pg2mysql.Conversions{TimeZone: "Europe/Berlin"}
+				err := pg2mysql.NewVerifier(pg, utcMySQL, conversions, pg2mysql.VerifyMethodDiff, nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				var diffs []pg2mysql.ColumnDiff
+				for i := 0; i < watcher.TableVerificationDidFindColumnDiffsCallCount(); i++ {
+					tableName, rowDiffs := watcher.TableVerificationDidFindColumnDiffsArgsForCall(i)
+					if tableName == "table_with_timestamptz" {
+						diffs = append(diffs, rowDiffs...)
+					}
+				}
+				Expect(diffs).To(ContainElement(pg2mysql.ColumnDiff{
+					ID: "2", ColumnName: "happened_at", Source: "2021-03-28 03:30:00 +02:00", Destination: "2021-03-28 03:30:00 +00:00", Explanation: pg2mysql.ExplanationTimeZone,
+				}))
+			})
+		})
+
+		Context("when time columns have fractional seconds", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_fsp (id integer NOT NULL, micros timestamp(6), millis timestamp(6))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_fsp (`id` integer NOT NULL, `micros` datetime(6), `millis` datetime(3))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_fsp (id, micros, millis) VALUES
+					(1, '2021-01-01 10:00:00.123456', '2021-01-01 10:00:00.123'),
+					(2, '2021-01-01 10:00:00.5', '2021-01-01 10:00:00.123456'),
+					(3, NULL, '2021-01-01 10:00:00.000001')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_fsp (id, micros, millis) VALUES
+					(1, '2021-01-01 10:00:00.123456', '2021-01-01 10:00:00.123'),
+					(2, '2021-01-01 10:00:00.5', '2021-01-01 10:00:00.123'),
+					(3, NULL, '2021-01-01 10:00:00.000')`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_fsp`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_fsp")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares times at the precision of each destination column", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					_, missingRows, _, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					Expect(missingRows).To(BeZero())
+				}
+			})
+		})
+
+		Context("when there are interval, time and date columns", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_time_types (id integer NOT NULL, duration interval, elapsed interval, opens_at time with time zone, closes_at time(6), born_on date)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_time_types (`id` integer NOT NULL, `duration` bigint, `elapsed` time, `opens_at` time, `closes_at` time(3), `born_on` date)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_time_types (id, duration, elapsed, opens_at, closes_at, born_on) VALUES
+					(1, '1 day 02:00:00', '-30 hours 15 minutes', '10:00:00+02', '18:30:00.123456', '2000-02-29'),
+					(2, NULL, NULL, NULL, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_time_types (id, duration, elapsed, opens_at, closes_at, born_on) VALUES
+					(1, 93600, '-29:45:00', '08:00:00', '18:30:00.123', '2000-02-29'),
+					(2, NULL, NULL, NULL, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_time_types`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_time_types")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares the converted values", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					_, missingRows, _, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					Expect(missingRows).To(BeZero())
+				}
+			})
+		})
+
+		Context("when there are network addresses and bit strings", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE TABLE table_with_network (id integer NOT NULL, address inet, network cidr, mac macaddr, flags varbit(8))`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_network (`id` integer NOT NULL, `address` varbinary(16), `network` varchar(18), `mac` binary(6), `flags` bit(4))")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_network (id, address, network, mac, flags) VALUES
+					(1, '192.168.0.1', '10.0.0.0/8', '08:00:2b:01:02:03', B'0101'),
+					(2, '2001:db8::1', NULL, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_network (id, address, network, mac, flags) VALUES
+					(1, INET6_ATON('192.168.0.1'), '10.0.0.0/8', UNHEX('08002B010203'), b'0101'),
+					(2, INET6_ATON('2001:db8::1'), NULL, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_network`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_network")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares the converted values", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					_, missingRows, _, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					Expect(missingRows).To(BeZero())
+				}
+			})
+		})
+
+		Context("when there are geometries", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE EXTENSION IF NOT EXISTS postgis`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`CREATE TABLE table_with_geometry (id integer NOT NULL, location geometry(Point,4326), shape geometry)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_geometry (`id` integer NOT NULL, `location` point, `shape` polygon)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_geometry (id, location, shape) VALUES
+					(1, ST_GeomFromText('POINT(1 2)', 4326), ST_GeomFromText('POLYGON((0 0,1 0,1 1,0 0))')),
+					(2, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_geometry (id, location, shape) VALUES
+					(1, ST_GeomFromText('POINT(1 2)', 4326), ST_GeomFromText('POLYGON((0 0,1 0,1 1,0 0))')),
+					(2, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_geometry`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_geometry")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares geometries by their WKB", func() {
+				err := verifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					_, missingRows, _, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					Expect(missingRows).To(BeZero())
+				}
+			})
+		})
+
+		Context("when there are ranges and hstores", func() {
+			BeforeEach(func() {
+				_, err := pgRunner.DB().Exec(`CREATE EXTENSION IF NOT EXISTS hstore`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = pgRunner.DB().Exec(`CREATE TABLE table_with_ranges (id integer NOT NULL, period tstzrange, seats int4range, attrs hstore)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("CREATE TABLE table_with_ranges (`id` integer NOT NULL, `period_start` datetime, `period_end` datetime, `seats` json, `attrs` json)")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = pgRunner.DB().Exec(`INSERT INTO table_with_ranges (id, period, seats, attrs) VALUES
+					(1, '[2021-01-01 10:00:00+00,2021-01-02 10:00:00+00)', '[1,5)', 'a=>1, b=>NULL'),
+					(2, NULL, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec(`INSERT INTO table_with_ranges (id, period_start, period_end, seats, attrs) VALUES
+					(1, '2021-01-01 10:00:00', '2021-01-02 10:00:00', '{"lower": 1, "upper": 5, "bounds": "[)"}', '{"a": "1", "b": null}'),
+					(2, NULL, NULL, NULL, NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				_, err := pgRunner.DB().Exec(`DROP TABLE table_with_ranges`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = mysqlRunner.DB().Exec("DROP TABLE table_with_ranges")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compares the split bounds and JSON documents", func() {
+				conversions := &pg2mysql.Conversions{
+					SplitRanges: map[string]map[string]pg2mysql.RangeColumns{
+						"table_with_ranges": {"period": {Lower: "period_start", Upper: "period_end"}},
+					},
+				}
+				err := pg2mysql.NewVerifier(pg, mysql, conversions, "", nil, watcher).Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watcher.TableVerificationDidFinishWithErrorCallCount()).To(BeZero())
+
+				for i := 0; i < watcher.TableVerificationDidFinishCallCount(); i++ {
+					_, missingRows, _, _, _ := watcher.TableVerificationDidFinishArgsForCall(i)
+					Expect(missingRows).To(BeZero())
+				}
+			})
+		})
 	})
 })
 
@@ -9,8 +9,11 @@ import (
 
 type VerifierWatcher interface {
 	TableVerificationDidStart(tableName string)
-	TableVerificationDidFinish(tableName string, missingRows int64, missingIDs []string)
+	TableVerificationDidFinish(tableName string, missingRows int64, missingIDs []string, extraRows int64, extraIDs []string)
 	TableVerificationDidFinishWithError(tableName string, err error)
+	TableVerificationDidFindSchemaProblems(tableName string, problems []string)
+	TableVerificationDidFindDifferences(tableName string, missingIDs, extraIDs, changedIDs []string)
+	TableVerificationDidFindColumnDiffs(tableName string, diffs []ColumnDiff)
 }
 
 //go:generate counterfeiter . MigratorWatcher
@@ -47,21 +50,48 @@ func (s *StdoutPrinter) TableVerificationDidStart(tableName string) {
 	fmt.Printf("Verifying table %s...", tableName)
 }
 
-func (s *StdoutPrinter) TableVerificationDidFinish(tableName string, missingRows int64, missingIDs []string) {
-	if missingRows != 0 {
-		if missingRows == 1 {
-			fmt.Println("\n\tFAILED: 1 row missing")
-		} else {
-			fmt.Printf("\n\tFAILED: %d rows missing\n", missingRows)
-		}
+func (s *StdoutPrinter) TableVerificationDidFinish(tableName string, missingRows int64, missingIDs []string, extraRows int64, extraIDs []string) {
+	if missingRows != 0 || extraRows != 0 {
+		fmt.Printf("\n\tFAILED: %s missing, %s extra\n", rowCount(missingRows), rowCount(extraRows))
 		if missingIDs != nil {
 			fmt.Printf("\tMissing IDs: %v\n", strings.Join(missingIDs, ","))
 		}
+		if extraIDs != nil {
+			fmt.Printf("\tExtra IDs: %v\n", strings.Join(extraIDs, ","))
+		}
 	} else {
 		s.done()
 	}
 }
 
+func rowCount(rows int64) string {
+	if rows == 1 {
+		return "1 row"
+	}
+	return fmt.Sprintf("%d rows", rows)
+}
+
+// TableVerificationDidFindDifferences is told the keys of the rows that
+// differ, once a table whose checksums differ has been verified by key
+// ranges. Changed rows are among the missing rows of TableVerificationDidFinish,
+// and extra rows are reported by it already.
+func (s *StdoutPrinter) TableVerificationDidFindDifferences(tableName string, missingIDs, extraIDs, changedIDs []string) {
+	if len(changedIDs) > 0 {
+		fmt.Printf("\tChanged IDs: %v\n", strings.Join(changedIDs, ","))
+	}
+}
+
+// TableVerificationDidFindColumnDiffs is told the columns that differ in a
+// row of a table verified by the diff method, before the table finishes.
+func (s *StdoutPrinter) TableVerificationDidFindColumnDiffs(tableName string, diffs []ColumnDiff) {
+	for _, diff := range diffs {
+		fmt.Printf("\n\tID %s, %s: %q != %q", diff.ID, diff.ColumnName, diff.Source, diff.Destination)
+		if diff.Explainable() {
+			fmt.Printf(" (%s)", diff.Explanation)
+		}
+	}
+}
+
 func (s *StdoutPrinter) done() {
 	fmt.Println("OK")
 }
@@ -70,6 +100,12 @@ func (s *StdoutPrinter) TableVerificationDidFinishWithError(tableName string, er
 	fmt.Printf("failed: %s", err)
 }
 
+// TableVerificationDidFindSchemaProblems is told of the schema problems of
+// a table: a table missing from either side, or columns that differ.
+func (s *StdoutPrinter) TableVerificationDidFindSchemaProblems(tableName string, problems []string) {
+	fmt.Printf("\n\tFAILED: %s\n", strings.Join(problems, ", "))
+}
+
 func (s *StdoutPrinter) WillBuildSchema() {
 	fmt.Print("Building schema...")
 }